@@ -0,0 +1,273 @@
+package netdb
+
+import "sort"
+
+// ServicePortConflicts returns all port and protocol pairs that are
+// claimed by more than one entry in Services, mapped to the entries
+// claiming them.
+func ServicePortConflicts() map[PortProtoKey][]*Servent {
+	byKey := make(map[PortProtoKey][]*Servent)
+	for _, servent := range Services {
+		key := servent.key()
+		byKey[key] = append(byKey[key], servent)
+	}
+
+	conflicts := make(map[PortProtoKey][]*Servent)
+	for key, servents := range byKey {
+		if len(servents) > 1 {
+			conflicts[key] = servents
+		}
+	}
+	return conflicts
+}
+
+// ProtocolAliasConflicts returns all names that more than one entry
+// in Protocols uses as its name or as an alias, mapped to the entries
+// using them.
+func ProtocolAliasConflicts() map[string][]*Protoent {
+	byName := make(map[string][]*Protoent)
+	for _, protoent := range Protocols {
+		for _, name := range protoent.names() {
+			byName[name] = append(byName[name], protoent)
+		}
+	}
+
+	conflicts := make(map[string][]*Protoent)
+	for name, protocols := range byName {
+		if len(protocols) > 1 {
+			conflicts[name] = protocols
+		}
+	}
+	return conflicts
+}
+
+// ServiceAliasConflicts returns all names that more than one entry in
+// Services of the same protocol uses as its name or as an alias,
+// mapped to the entries using them. If the protocol is nil, the
+// conflicts within every protocol are returned.
+func ServiceAliasConflicts(protocol *Protoent) map[string][]*Servent {
+	type key struct {
+		name  string
+		proto string
+	}
+
+	var order []key
+	byKey := make(map[key][]*Servent)
+	for _, servent := range Services {
+		if !servent.hasProtocol(protocol) {
+			continue
+		}
+		proto := servent.key().Proto
+		for _, name := range servent.names() {
+			k := key{name, proto}
+			if _, ok := byKey[k]; !ok {
+				order = append(order, k)
+			}
+			byKey[k] = append(byKey[k], servent)
+		}
+	}
+
+	conflicts := make(map[string][]*Servent)
+	for _, k := range order {
+		if servents := byKey[k]; len(servents) > 1 {
+			conflicts[k.name] = append(conflicts[k.name], servents...)
+		}
+	}
+	return conflicts
+}
+
+// PortsWithMultipleProtocols returns all ports used by services of
+// more than one protocol, mapped to those protocols sorted by name.
+// For port 53, this usually is TCP and UDP.
+func PortsWithMultipleProtocols() map[int][]*Protoent {
+	byPort := make(map[int][]*Protoent)
+	for _, servent := range Services {
+		if servent.Protocol == nil {
+			continue
+		}
+		if !containsProtocol(byPort[servent.Port], servent.Protocol) {
+			byPort[servent.Port] = append(byPort[servent.Port], servent.Protocol)
+		}
+	}
+
+	ports := make(map[int][]*Protoent)
+	for port, protocols := range byPort {
+		if len(protocols) < 2 {
+			continue
+		}
+		sort.Slice(protocols, func(i, j int) bool {
+			return protocols[i].Name < protocols[j].Name
+		})
+		ports[port] = protocols
+	}
+	return ports
+}
+
+func containsProtocol(protocols []*Protoent, protocol *Protoent) bool {
+	for _, protoent := range protocols {
+		if protoent.Equal(protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceNameCollisions returns all names that entries in Services of
+// different protocols use, as their name or as an alias, for
+// different ports, mapped to all entries using them. A name used for
+// 80/tcp and 8080/udp is a collision, while domain on 53/tcp and
+// 53/udp is not.
+func ServiceNameCollisions() map[string][]*Servent {
+	byName := make(map[string][]*Servent)
+	for _, servent := range Services {
+		for _, name := range servent.names() {
+			byName[name] = append(byName[name], servent)
+		}
+	}
+
+	collisions := make(map[string][]*Servent)
+	for name, servents := range byName {
+	search:
+		for i, a := range servents {
+			for _, b := range servents[i+1:] {
+				ka, kb := a.key(), b.key()
+				if ka.Proto != kb.Proto && ka.Port != kb.Port {
+					collisions[name] = servents
+					break search
+				}
+			}
+		}
+	}
+	return collisions
+}
+
+// A ProtocolGap is an inclusive range of protocol numbers that have
+// no entry in Protocols.
+type ProtocolGap struct {
+	From, To int
+}
+
+// ProtocolsWithNumberGap returns the ranges of protocol numbers in
+// [0, MaxProtocolNumber] that have no entry in Protocols, in
+// ascending order.
+func ProtocolsWithNumberGap() []ProtocolGap {
+	used := make(map[int]bool, len(Protocols))
+	for _, protoent := range Protocols {
+		used[protoent.Number] = true
+	}
+
+	var gaps []ProtocolGap
+	eachGap(0, MaxProtocolNumber, used, func(from, to int) {
+		gaps = append(gaps, ProtocolGap{From: from, To: to})
+	})
+	return gaps
+}
+
+// PortsWithGap returns the ranges of ports in [min, max] that have no
+// service of the protocol in Services, in ascending order. If the
+// protocol is nil, a port is considered used if it has a service of
+// any protocol.
+func PortsWithGap(protocol *Protoent, min, max int) []PortRange {
+	used := make(map[int]bool)
+	for _, servent := range Services {
+		if servent.hasProtocol(protocol) {
+			used[servent.Port] = true
+		}
+	}
+
+	var gaps []PortRange
+	eachGap(min, max, used, func(from, to int) {
+		gaps = append(gaps, PortRange{From: from, To: to})
+	})
+	return gaps
+}
+
+// ProtocolCoverage returns the fraction of the protocol numbers in
+// [0, MaxProtocolNumber] that have an entry in Protocols, from 0 to 1.
+func ProtocolCoverage() float64 {
+	set := ProtocolNumberSet()
+	n := 0
+	for num := 0; num <= MaxProtocolNumber; num++ {
+		if set.Contains(num) {
+			n++
+		}
+	}
+	return float64(n) / (MaxProtocolNumber + 1)
+}
+
+// WellKnownPortCoverage returns the fraction of the well-known ports
+// [0, 1023] that have a service of the protocol in Services, from 0
+// to 1. If the protocol is nil, services of all protocols are
+// considered.
+func WellKnownPortCoverage(protocol *Protoent) float64 {
+	set := ServicePortSet(protocol)
+	n := 0
+	for port := 0; port <= 1023; port++ {
+		if set.Contains(port) {
+			n++
+		}
+	}
+	return float64(n) / 1024
+}
+
+// eachGap calls fn for every maximal range within [min, max] whose
+// numbers are all absent from used.
+func eachGap(min, max int, used map[int]bool, fn func(from, to int)) {
+	from := -1
+	for n := min; n <= max; n++ {
+		switch {
+		case !used[n] && from < 0:
+			from = n
+		case used[n] && from >= 0:
+			fn(from, n-1)
+			from = -1
+		}
+	}
+	if from >= 0 {
+		fn(from, max)
+	}
+}
+
+// GroupByPortParity splits Services into the entries on even ports
+// and those on odd ports.
+func GroupByPortParity() (even []*Servent, odd []*Servent) {
+	for _, servent := range Services {
+		if servent.Port%2 == 0 {
+			even = append(even, servent)
+		} else {
+			odd = append(odd, servent)
+		}
+	}
+	return even, odd
+}
+
+// A ServicePair holds two services of the same protocol on
+// consecutive ports.
+type ServicePair struct {
+	Low, High *Servent
+}
+
+// ConsecutivePairs returns all pairs of services of the same protocol
+// whose ports are consecutive, such as ftp-data (20/tcp) and ftp
+// (21/tcp). Only the first service on each port and protocol is
+// considered. Pairs are sorted by protocol and port.
+func ConsecutivePairs() []ServicePair {
+	first := make(map[PortProtoKey]*Servent)
+	for _, servent := range Services {
+		if _, ok := first[servent.key()]; !ok {
+			first[servent.key()] = servent
+		}
+	}
+
+	var pairs []ServicePair
+	for _, servent := range sortedServices() {
+		key := servent.key()
+		if first[key] != servent {
+			continue
+		}
+		if next, ok := first[NewPortProtoKey(key.Port+1, key.Proto)]; ok {
+			pairs = append(pairs, ServicePair{Low: servent, High: next})
+		}
+	}
+	return pairs
+}
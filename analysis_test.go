@@ -0,0 +1,27 @@
+package netdb_test
+
+import (
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+func TestProtocolCoverage(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	if got, want := netdb.ProtocolCoverage(), 3.0/256; got != want {
+		t.Errorf("ProtocolCoverage() = %v, want %v", got, want)
+	}
+}
+
+func TestWellKnownPortCoverage(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	if got, want := netdb.WellKnownPortCoverage(nil), 4.0/1024; got != want {
+		t.Errorf("WellKnownPortCoverage(nil) = %v, want %v", got, want)
+	}
+	if got, want := netdb.WellKnownPortCoverage(netdb.GetProtoByName("udp")), 1.0/1024; got != want {
+		t.Errorf("WellKnownPortCoverage(udp) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,237 @@
+package netdb_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+// useSynthetic replaces netdb.Protocols and netdb.Services with
+// netdbtest.Synthetic(n) for the duration of the benchmark.
+func useSynthetic(b *testing.B, n int) {
+	oldProtocols, oldServices := netdb.Protocols, netdb.Services
+	netdb.Protocols, netdb.Services = netdbtest.Synthetic(n)
+	b.Cleanup(func() {
+		netdb.Protocols, netdb.Services = oldProtocols, oldServices
+	})
+}
+
+func BenchmarkGetProtoByNumber(b *testing.B) {
+	useSynthetic(b, 100)
+
+	b.Run("found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetProtoByNumber(50) == nil {
+				b.Fatal("protocol not found")
+			}
+		}
+	})
+	b.Run("not found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetProtoByNumber(200) != nil {
+				b.Fatal("unexpected protocol")
+			}
+		}
+	})
+}
+
+func BenchmarkGetProtoByName(b *testing.B) {
+	useSynthetic(b, 100)
+
+	b.Run("found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetProtoByName("proto-050") == nil {
+				b.Fatal("protocol not found")
+			}
+		}
+	})
+	b.Run("not found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetProtoByName("nonexistent") != nil {
+				b.Fatal("unexpected protocol")
+			}
+		}
+	})
+}
+
+func BenchmarkGetServByPort(b *testing.B) {
+	useSynthetic(b, 100)
+	protocol := netdb.GetProtoByNumber(50)
+
+	b.Run("found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetServByPort(50, protocol) == nil {
+				b.Fatal("service not found")
+			}
+		}
+	})
+	b.Run("not found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetServByPort(5000, protocol) != nil {
+				b.Fatal("unexpected service")
+			}
+		}
+	})
+}
+
+func BenchmarkGetServByName(b *testing.B) {
+	useSynthetic(b, 100)
+	protocol := netdb.GetProtoByNumber(50)
+
+	b.Run("found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetServByName("svc-050", protocol) == nil {
+				b.Fatal("service not found")
+			}
+		}
+	})
+	b.Run("not found", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetServByName("nonexistent", protocol) != nil {
+				b.Fatal("unexpected service")
+			}
+		}
+	})
+}
+
+// BenchmarkServentRoundTrip compares a round trip of 10000 services
+// through MarshalBinary and UnmarshalBinary with one through gob.
+// gob would use MarshalBinary itself, so a plain struct with the same
+// information is encoded instead.
+func BenchmarkServentRoundTrip(b *testing.B) {
+	useSynthetic(b, 100)
+
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, servent := range netdb.Services {
+				data, err := servent.MarshalBinary()
+				if err != nil {
+					b.Fatal(err)
+				}
+				var decoded netdb.Servent
+				if err := decoded.UnmarshalBinary(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("gob", func(b *testing.B) {
+		type plainServent struct {
+			Name     string
+			Aliases  []string
+			Port     int
+			Protocol string
+		}
+		plain := make([]plainServent, len(netdb.Services))
+		for i, servent := range netdb.Services {
+			plain[i] = plainServent{servent.Name, servent.Aliases, servent.Port, servent.Protocol.Name}
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(plain); err != nil {
+				b.Fatal(err)
+			}
+			var decoded []plainServent
+			if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkParseServices compares ParseServicesBytes with scanning the
+// same data from an io.Reader and parsing each line with
+// ParseServiceLine.
+func BenchmarkParseServices(b *testing.B) {
+	useSynthetic(b, 100)
+
+	var buf bytes.Buffer
+	for _, servent := range netdb.Services {
+		text, err := servent.MarshalText()
+		if err != nil {
+			b.Fatal(err)
+		}
+		buf.Write(text)
+		buf.WriteString("\n")
+	}
+	data := buf.Bytes()
+
+	b.Run("bytes", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if _, err := netdb.ParseServicesBytes(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reader", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			var services []*netdb.Servent
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			for scanner.Scan() {
+				servent, err := netdb.ParseServiceLine(scanner.Text())
+				if err != nil {
+					b.Fatal(err)
+				}
+				if servent != nil {
+					services = append(services, servent)
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSubsetByProtocol(b *testing.B) {
+	useSynthetic(b, 100)
+	protocol := netdb.GetProtoByNumber(50)
+
+	b.Run("protocol", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if len(netdb.SubsetByProtocol(protocol)) != 100 {
+				b.Fatal("wrong number of services")
+			}
+		}
+	})
+	b.Run("nil", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if len(netdb.SubsetByProtocol(nil)) != len(netdb.Services) {
+				b.Fatal("wrong number of services")
+			}
+		}
+	})
+}
+
+// BenchmarkByServicePort compares a lookup in the snapshot returned by
+// ByServicePort with GetServByPort.
+func BenchmarkByServicePort(b *testing.B) {
+	useSynthetic(b, 100)
+	protocol := netdb.GetProtoByNumber(50)
+	m := netdb.ByServicePort()
+
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, ok := m[50]["proto-050"]; !ok {
+				b.Fatal("service not found")
+			}
+		}
+	})
+	b.Run("GetServByPort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if netdb.GetServByPort(50, protocol) == nil {
+				b.Fatal("service not found")
+			}
+		}
+	})
+}
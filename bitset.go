@@ -0,0 +1,51 @@
+package netdb
+
+// A PortBitset records a set of ports, one bit per port.
+type PortBitset [1024]uint64
+
+// Contains reports whether port is in the set.
+func (this *PortBitset) Contains(port int) bool {
+	if port < MinServicePort || port > MaxServicePort {
+		return false
+	}
+	return this[port>>6]&(1<<(uint(port)&63)) != 0
+}
+
+// ServicePortSet returns the set of ports that have a service of the
+// protocol in Services. If the protocol is nil, services of all
+// protocols are considered. The set is a snapshot and does not
+// reflect later changes to Services.
+func ServicePortSet(protocol *Protoent) *PortBitset {
+	set := new(PortBitset)
+	for _, servent := range Services {
+		if servent.hasProtocol(protocol) && servent.Port >= MinServicePort && servent.Port <= MaxServicePort {
+			set[servent.Port>>6] |= 1 << (uint(servent.Port) & 63)
+		}
+	}
+	return set
+}
+
+// A ProtoBitset records a set of IP protocol numbers, one bit per
+// number.
+type ProtoBitset [4]uint64
+
+// Contains reports whether the protocol number n is in the set.
+func (this *ProtoBitset) Contains(n int) bool {
+	if n < 0 || n > MaxProtocolNumber {
+		return false
+	}
+	return this[n>>6]&(1<<(uint(n)&63)) != 0
+}
+
+// ProtocolNumberSet returns the set of protocol numbers that have an
+// entry in Protocols. The set is a snapshot and does not reflect
+// later changes to Protocols.
+func ProtocolNumberSet() *ProtoBitset {
+	set := new(ProtoBitset)
+	for _, protoent := range Protocols {
+		if protoent.Number >= 0 && protoent.Number <= MaxProtocolNumber {
+			set[protoent.Number>>6] |= 1 << (uint(protoent.Number) & 63)
+		}
+	}
+	return set
+}
@@ -0,0 +1,169 @@
+package netdb
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProtocolsNotIn returns all entries in Protocols whose number does
+// not appear in other.
+func ProtocolsNotIn(other []*Protoent) []*Protoent {
+	numbers := protocolNumbers(other)
+
+	var out []*Protoent
+	for _, protoent := range Protocols {
+		if _, ok := numbers[protoent.Number]; !ok {
+			out = append(out, protoent)
+		}
+	}
+	return out
+}
+
+// ServicesNotIn returns all entries in Services whose port and
+// protocol do not appear in other.
+func ServicesNotIn(other []*Servent) []*Servent {
+	keys := serviceKeys(other)
+
+	var out []*Servent
+	for _, servent := range Services {
+		if _, ok := keys[servent.key()]; !ok {
+			out = append(out, servent)
+		}
+	}
+	return out
+}
+
+func protocolNumbers(protocols []*Protoent) map[int]struct{} {
+	numbers := make(map[int]struct{}, len(protocols))
+	for _, protoent := range protocols {
+		numbers[protoent.Number] = struct{}{}
+	}
+	return numbers
+}
+
+func serviceKeys(services []*Servent) map[PortProtoKey]struct{} {
+	keys := make(map[PortProtoKey]struct{}, len(services))
+	for _, servent := range services {
+		keys[servent.key()] = struct{}{}
+	}
+	return keys
+}
+
+// ProtocolsInBoth returns all entries in Protocols whose number also
+// appears in other. The returned entries are taken from Protocols.
+func ProtocolsInBoth(other []*Protoent) []*Protoent {
+	numbers := protocolNumbers(other)
+
+	var out []*Protoent
+	for _, protoent := range Protocols {
+		if _, ok := numbers[protoent.Number]; ok {
+			out = append(out, protoent)
+		}
+	}
+	return out
+}
+
+// ServicesInBoth returns all entries in Services whose port and
+// protocol also appear in other. The returned entries are taken from
+// Services.
+func ServicesInBoth(other []*Servent) []*Servent {
+	keys := serviceKeys(other)
+
+	var out []*Servent
+	for _, servent := range Services {
+		if _, ok := keys[servent.key()]; ok {
+			out = append(out, servent)
+		}
+	}
+	return out
+}
+
+// ProtocolsEqual reports whether Protocols and other contain the same
+// protocols, regardless of their order. Unlike ProtocolsNotIn,
+// protocols only match if their number, name and aliases are the
+// same. The order of aliases is not significant.
+func ProtocolsEqual(other []*Protoent) bool {
+	if len(Protocols) != len(other) {
+		return false
+	}
+	return equalStrings(protocolEntryKeys(Protocols), protocolEntryKeys(other))
+}
+
+// ServicesEqual reports whether Services and other contain the same
+// services, regardless of their order. Unlike ServicesNotIn, services
+// only match if their port, protocol, name and aliases are the same.
+// The order of aliases is not significant.
+func ServicesEqual(other []*Servent) bool {
+	if len(Services) != len(other) {
+		return false
+	}
+	return equalStrings(serviceEntryKeys(Services), serviceEntryKeys(other))
+}
+
+// ProtocolsContain reports whether every entry in other has an entry
+// in Protocols with the same number, name and aliases. The order of
+// aliases is not significant.
+func ProtocolsContain(other []*Protoent) bool {
+	return containsStrings(protocolEntryKeys(Protocols), protocolEntryKeys(other))
+}
+
+// ServicesContain reports whether every entry in other has an entry
+// in Services with the same port, protocol, name and aliases. The
+// order of aliases is not significant.
+func ServicesContain(other []*Servent) bool {
+	return containsStrings(serviceEntryKeys(Services), serviceEntryKeys(other))
+}
+
+// protocolEntryKeys returns a sorted list of strings identifying the
+// protocols by number, name and aliases.
+func protocolEntryKeys(protocols []*Protoent) []string {
+	keys := make([]string, len(protocols))
+	for i, protoent := range protocols {
+		keys[i] = entryKey(strconv.Itoa(protoent.Number), protoent.Name, protoent.Aliases)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// serviceEntryKeys returns a sorted list of strings identifying the
+// services by port, protocol, name and aliases.
+func serviceEntryKeys(services []*Servent) []string {
+	keys := make([]string, len(services))
+	for i, servent := range services {
+		key := servent.key()
+		keys[i] = entryKey(strconv.Itoa(key.Port)+"/"+key.Proto, servent.Name, servent.Aliases)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func entryKey(id, name string, aliases []string) string {
+	sorted := append([]string(nil), aliases...)
+	sort.Strings(sorted)
+	return id + "\x00" + name + "\x00" + strings.Join(sorted, "\x00")
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// containsStrings reports whether every string in sub is in set. Both
+// must be sorted.
+func containsStrings(set, sub []string) bool {
+	for _, s := range sub {
+		i := sort.SearchStrings(set, s)
+		if i == len(set) || set[i] != s {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package netdb_test
+
+import (
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+func TestProtocolsEqual(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	protocols, _ := netdbtest.Fixture()
+	protocols[0], protocols[2] = protocols[2], protocols[0]
+	if !netdb.ProtocolsEqual(protocols) {
+		t.Errorf("ProtocolsEqual is false for a reordered copy")
+	}
+
+	protocols[1].Aliases = []string{"TCP", "tcp-proto"}
+	if netdb.ProtocolsEqual(protocols) {
+		t.Errorf("ProtocolsEqual is true although the aliases differ")
+	}
+	if len(netdb.ProtocolsNotIn(protocols)) != 0 {
+		t.Errorf("ProtocolsNotIn unexpectedly considers aliases")
+	}
+}
+
+func TestServicesEqual(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	_, services := netdbtest.Fixture()
+	services[0], services[4] = services[4], services[0]
+	if !netdb.ServicesEqual(services) {
+		t.Errorf("ServicesEqual is false for a reordered copy")
+	}
+
+	services[1].Name = "https-renamed"
+	if netdb.ServicesEqual(services) {
+		t.Errorf("ServicesEqual is true although a name differs")
+	}
+	if netdb.ServicesEqual(services[1:]) {
+		t.Errorf("ServicesEqual is true for lists of different length")
+	}
+}
+
+func TestProtocolsContain(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	protocols, _ := netdbtest.Fixture()
+	if !netdb.ProtocolsContain(protocols[1:]) {
+		t.Errorf("ProtocolsContain is false for a subset")
+	}
+	protocols[1].Name = "tcp-renamed"
+	if netdb.ProtocolsContain(protocols[1:]) {
+		t.Errorf("ProtocolsContain is true although a name differs")
+	}
+}
+
+func TestServicesContain(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	_, services := netdbtest.Fixture()
+	if !netdb.ServicesContain(services[3:]) {
+		t.Errorf("ServicesContain is false for a subset")
+	}
+	services[3].Aliases = nil
+	if netdb.ServicesContain(services[3:]) {
+		t.Errorf("ServicesContain is true although the aliases differ")
+	}
+}
@@ -0,0 +1,267 @@
+package netdb
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Protoent)(nil)
+	_ encoding.TextUnmarshaler = (*Protoent)(nil)
+	_ encoding.TextMarshaler   = (*Servent)(nil)
+	_ encoding.TextUnmarshaler = (*Servent)(nil)
+
+	_ encoding.BinaryMarshaler   = (*Protoent)(nil)
+	_ encoding.BinaryUnmarshaler = (*Protoent)(nil)
+	_ encoding.BinaryMarshaler   = (*Servent)(nil)
+	_ encoding.BinaryUnmarshaler = (*Servent)(nil)
+
+	_ json.Marshaler   = (*Protoent)(nil)
+	_ json.Unmarshaler = (*Protoent)(nil)
+	_ json.Marshaler   = (*Servent)(nil)
+	_ json.Unmarshaler = (*Servent)(nil)
+)
+
+var errShortBuffer = errors.New("netdb: binary data too short")
+
+// MarshalText implements encoding.TextMarshaler. The result is the
+// protocol formatted as a line of /etc/protocols.
+func (this *Protoent) MarshalText() ([]byte, error) {
+	line := this.Name + "\t" + strconv.Itoa(this.Number)
+	if len(this.Aliases) > 0 {
+		line += "\t" + strings.Join(this.Aliases, " ")
+	}
+	return []byte(line), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing text
+// with ParseProtocolLine.
+func (this *Protoent) UnmarshalText(text []byte) error {
+	protoent, err := parseProtocolLine(text)
+	if err != nil {
+		return err
+	}
+	if protoent == nil {
+		return errors.New("netdb: text contains no protocol entry")
+	}
+
+	*this = *protoent
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The result is the
+// service formatted as a line of /etc/services. It is an error for
+// the service to have no protocol.
+func (this *Servent) MarshalText() ([]byte, error) {
+	if this.Protocol == nil {
+		return nil, errors.New("netdb: service has no protocol")
+	}
+
+	line := this.Name + "\t" + strconv.Itoa(this.Port) + "/" + this.Protocol.Name
+	if len(this.Aliases) > 0 {
+		line += "\t" + strings.Join(this.Aliases, " ")
+	}
+	return []byte(line), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing text
+// with ParseServiceLine. If the protocol is not in Protocols, an
+// error wrapping ErrNotFound is returned and the receiver is left
+// unchanged.
+func (this *Servent) UnmarshalText(text []byte) error {
+	var proto string
+	servent, err := parseServiceLine(text, func(name []byte) *Protoent {
+		proto = string(name)
+		return protoByName(name)
+	})
+	if err != nil {
+		return err
+	}
+	if servent == nil {
+		return errors.New("netdb: text contains no service entry")
+	}
+	if servent.Protocol == nil {
+		return fmt.Errorf("%w: protocol %s", ErrNotFound, proto)
+	}
+
+	*this = *servent
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Protocols are encoded as JSON
+// objects with the fields Name, Aliases and Number. Without it,
+// encoding/json would use MarshalText.
+func (this *Protoent) MarshalJSON() ([]byte, error) {
+	type protoent Protoent
+	return json.Marshal((*protoent)(this))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It decodes the object
+// encoding produced by MarshalJSON.
+func (this *Protoent) UnmarshalJSON(data []byte) error {
+	type protoent Protoent
+	return json.Unmarshal(data, (*protoent)(this))
+}
+
+// MarshalJSON implements json.Marshaler. Services are encoded as JSON
+// objects with the fields Name, Aliases, Port and Protocol, where
+// Protocol is encoded like a Protoent. Without it, encoding/json
+// would use MarshalText.
+func (this *Servent) MarshalJSON() ([]byte, error) {
+	type servent Servent
+	return json.Marshal((*servent)(this))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It decodes the object
+// encoding produced by MarshalJSON. The decoded Protocol is a new
+// Protoent, not an entry of Protocols.
+func (this *Servent) UnmarshalJSON(data []byte) error {
+	type servent Servent
+	return json.Unmarshal(data, (*servent)(this))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The wire format
+// is:
+//
+//	number       uint16, big endian
+//	alias count  uint8
+//	name         string
+//	aliases      alias count strings
+//
+// where each string is encoded as a uint8 length followed by that
+// many bytes.
+func (this *Protoent) MarshalBinary() ([]byte, error) {
+	if this.Number < 0 || this.Number > 0xFFFF {
+		return nil, errors.New("netdb: protocol number out of range")
+	}
+	if len(this.Aliases) > 0xFF {
+		return nil, errors.New("netdb: too many aliases")
+	}
+
+	buf := make([]byte, 3, 4+len(this.Name))
+	binary.BigEndian.PutUint16(buf, uint16(this.Number))
+	buf[2] = byte(len(this.Aliases))
+
+	buf, err := appendStrings(buf, this.Name)
+	if err != nil {
+		return nil, err
+	}
+	return appendStrings(buf, this.Aliases...)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See
+// MarshalBinary for the wire format.
+func (this *Protoent) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 {
+		return errShortBuffer
+	}
+	number := int(binary.BigEndian.Uint16(data))
+	strs, err := readStrings(data[3:], 1+int(data[2]))
+	if err != nil {
+		return err
+	}
+
+	*this = Protoent{
+		Name:    strs[0],
+		Aliases: strs[1:],
+		Number:  number,
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The wire format
+// is:
+//
+//	port         uint16, big endian
+//	alias count  uint8
+//	name         string
+//	protocol     string, the protocol's name, empty if it is nil
+//	aliases      alias count strings
+//
+// where each string is encoded as a uint8 length followed by that
+// many bytes.
+func (this *Servent) MarshalBinary() ([]byte, error) {
+	if this.Port < MinServicePort || this.Port > MaxServicePort {
+		return nil, errors.New("netdb: port out of range")
+	}
+	if len(this.Aliases) > 0xFF {
+		return nil, errors.New("netdb: too many aliases")
+	}
+
+	var proto string
+	if this.Protocol != nil {
+		proto = this.Protocol.Name
+	}
+
+	buf := make([]byte, 3, 5+len(this.Name)+len(proto))
+	binary.BigEndian.PutUint16(buf, uint16(this.Port))
+	buf[2] = byte(len(this.Aliases))
+
+	buf, err := appendStrings(buf, this.Name, proto)
+	if err != nil {
+		return nil, err
+	}
+	return appendStrings(buf, this.Aliases...)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. See
+// MarshalBinary for the wire format. The protocol is resolved using
+// GetProtoByName. If it is not in Protocols, an error wrapping
+// ErrNotFound is returned and the receiver is left unchanged.
+func (this *Servent) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 {
+		return errShortBuffer
+	}
+	port := int(binary.BigEndian.Uint16(data))
+	strs, err := readStrings(data[3:], 2+int(data[2]))
+	if err != nil {
+		return err
+	}
+
+	var protocol *Protoent
+	if strs[1] != "" {
+		protocol = GetProtoByName(strs[1])
+		if protocol == nil {
+			return fmt.Errorf("%w: protocol %s", ErrNotFound, strs[1])
+		}
+	}
+
+	*this = Servent{
+		Name:     strs[0],
+		Aliases:  strs[2:],
+		Port:     port,
+		Protocol: protocol,
+	}
+	return nil
+}
+
+func appendStrings(buf []byte, strs ...string) ([]byte, error) {
+	for _, s := range strs {
+		if len(s) > 0xFF {
+			return nil, errors.New("netdb: string too long")
+		}
+		buf = append(buf, byte(len(s)))
+		buf = append(buf, s...)
+	}
+	return buf, nil
+}
+
+func readStrings(data []byte, n int) ([]string, error) {
+	strs := make([]string, n)
+	for i := range strs {
+		if len(data) < 1 || len(data) < 1+int(data[0]) {
+			return nil, errShortBuffer
+		}
+		strs[i] = string(data[1 : 1+int(data[0])])
+		data = data[1+int(data[0]):]
+	}
+	if len(data) != 0 {
+		return nil, errors.New("netdb: trailing binary data")
+	}
+	return strs, nil
+}
@@ -0,0 +1,211 @@
+package netdb_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*netdb.Protoent)(nil)
+	_ encoding.TextUnmarshaler = (*netdb.Protoent)(nil)
+	_ encoding.TextMarshaler   = (*netdb.Servent)(nil)
+	_ encoding.TextUnmarshaler = (*netdb.Servent)(nil)
+)
+
+func TestProtoentText(t *testing.T) {
+	tests := []struct {
+		protoent *netdb.Protoent
+		want     string
+	}{
+		{&netdb.Protoent{Name: "tcp", Aliases: []string{"TCP"}, Number: 6}, "tcp\t6\tTCP"},
+		{&netdb.Protoent{Name: "ipv6-icmp", Aliases: []string{"IPv6-ICMP", "icmp6"}, Number: 58}, "ipv6-icmp\t58\tIPv6-ICMP icmp6"},
+		{&netdb.Protoent{Name: "hopopt", Aliases: []string{}, Number: 0}, "hopopt\t0"},
+	}
+
+	for _, tt := range tests {
+		text, err := tt.protoent.MarshalText()
+		if err != nil {
+			t.Errorf("%s: %v", tt.protoent.Name, err)
+			continue
+		}
+		if string(text) != tt.want {
+			t.Errorf("got %q, want %q", text, tt.want)
+		}
+
+		var got netdb.Protoent
+		if err := got.UnmarshalText(text); err != nil {
+			t.Errorf("%q: %v", text, err)
+			continue
+		}
+		if !reflect.DeepEqual(&got, tt.protoent) {
+			t.Errorf("got %#v, want %#v", &got, tt.protoent)
+		}
+	}
+}
+
+func TestServentText(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	for _, servent := range netdb.Services {
+		text, err := servent.MarshalText()
+		if err != nil {
+			t.Errorf("%s: %v", servent.Name, err)
+			continue
+		}
+
+		var got netdb.Servent
+		if err := got.UnmarshalText(text); err != nil {
+			t.Errorf("%q: %v", text, err)
+			continue
+		}
+		if !reflect.DeepEqual(&got, servent) {
+			t.Errorf("%q: got %#v, want %#v", text, &got, servent)
+		}
+		if got.Protocol != servent.Protocol {
+			t.Errorf("%q: protocol was not resolved against Protocols", text)
+		}
+	}
+
+	if _, err := (&netdb.Servent{Name: "http", Port: 80}).MarshalText(); err == nil {
+		t.Errorf("MarshalText of a service without protocol succeeded")
+	}
+}
+
+func TestUnmarshalTextNoEntry(t *testing.T) {
+	for _, text := range []string{"", "   ", "# comment"} {
+		if err := new(netdb.Protoent).UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("Protoent.UnmarshalText(%q) succeeded", text)
+		}
+		if err := new(netdb.Servent).UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("Servent.UnmarshalText(%q) succeeded", text)
+		}
+	}
+}
+
+func TestProtoentJSON(t *testing.T) {
+	protoent := &netdb.Protoent{Name: "tcp", Aliases: []string{"TCP"}, Number: 6}
+	data, err := json.Marshal(protoent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"Name":"tcp","Aliases":["TCP"],"Number":6}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	var got netdb.Protoent
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, protoent) {
+		t.Errorf("got %#v, want %#v", &got, protoent)
+	}
+}
+
+func TestServentJSON(t *testing.T) {
+	tcp := &netdb.Protoent{Name: "tcp", Aliases: []string{"TCP"}, Number: 6}
+	tests := []struct {
+		servent *netdb.Servent
+		want    string
+	}{
+		{
+			&netdb.Servent{Name: "http", Aliases: []string{"www"}, Port: 80, Protocol: tcp},
+			`{"Name":"http","Aliases":["www"],"Port":80,"Protocol":{"Name":"tcp","Aliases":["TCP"],"Number":6}}`,
+		},
+		{
+			&netdb.Servent{Name: "http", Port: 80},
+			`{"Name":"http","Aliases":null,"Port":80,"Protocol":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.servent)
+		if err != nil {
+			t.Errorf("%s: %v", tt.servent.Name, err)
+			continue
+		}
+		if string(data) != tt.want {
+			t.Errorf("got %s, want %s", data, tt.want)
+		}
+
+		var got netdb.Servent
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Errorf("%s: %v", tt.want, err)
+			continue
+		}
+		if !reflect.DeepEqual(&got, tt.servent) {
+			t.Errorf("got %#v, want %#v", &got, tt.servent)
+		}
+	}
+}
+
+func TestSliceJSON(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	data, err := json.Marshal(netdb.Services)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var services []*netdb.Servent
+	if err := json.Unmarshal(data, &services); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(services, netdb.Services) {
+		t.Errorf("round trip of Services through JSON changed the entries")
+	}
+}
+
+func TestServentBinary(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	for _, servent := range netdb.Services {
+		data, err := servent.MarshalBinary()
+		if err != nil {
+			t.Errorf("%s: %v", servent.Name, err)
+			continue
+		}
+
+		var got netdb.Servent
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Errorf("%s: %v", servent.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(&got, servent) {
+			t.Errorf("got %#v, want %#v", &got, servent)
+		}
+	}
+}
+
+func TestServentBinaryUnknownProtocol(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	sctp := &netdb.Protoent{Name: "sctp", Number: 132}
+	data, err := (&netdb.Servent{Name: "diameter", Port: 3868, Protocol: sctp}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got netdb.Servent
+	if err := got.UnmarshalBinary(data); !errors.Is(err, netdb.ErrNotFound) {
+		t.Errorf("got error %v, want one wrapping ErrNotFound", err)
+	}
+}
+
+func TestServentTextUnknownProtocol(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	got := netdb.Servent{Name: "http", Port: 80}
+	want := got
+	if err := got.UnmarshalText([]byte("diameter 3868/sctp")); !errors.Is(err, netdb.ErrNotFound) {
+		t.Errorf("got error %v, want one wrapping ErrNotFound", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("receiver was modified to %#v", &got)
+	}
+}
@@ -0,0 +1,36 @@
+package netdb_test
+
+import (
+	"fmt"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+// useExampleData replaces the system's protocols and services with
+// netdbtest's fixture, so that the examples' output doesn't depend on
+// the host. The returned function restores the previous entries.
+func useExampleData() (restore func()) {
+	oldProtocols, oldServices := netdb.Protocols, netdb.Services
+	netdb.Protocols, netdb.Services = netdbtest.Fixture()
+	return func() {
+		netdb.Protocols, netdb.Services = oldProtocols, oldServices
+	}
+}
+
+func Example_getServByPort() {
+	defer useExampleData()()
+
+	tcp := netdb.GetProtoByName("tcp")
+	servent := netdb.GetServByPort(80, tcp)
+	fmt.Println(servent.Name, servent.Aliases)
+	// Output: http [www]
+}
+
+func Example_getProtoByName() {
+	defer useExampleData()()
+
+	protoent := netdb.GetProtoByName("UDP")
+	fmt.Println(protoent.Name, protoent.Number)
+	// Output: udp 17
+}
@@ -0,0 +1,152 @@
+package netdb
+
+import (
+	"path"
+	"sort"
+)
+
+// SubsetByProtocol returns all entries in Services of the given
+// protocol. If the protocol is nil, services of any protocol are
+// returned.
+func SubsetByProtocol(protocol *Protoent) []*Servent {
+	var out []*Servent
+	for _, servent := range Services {
+		if servent.hasProtocol(protocol) {
+			out = append(out, servent)
+		}
+	}
+	return out
+}
+
+// ServicesForIPProtocol returns all entries in Services whose
+// protocol has the given IP protocol number, such as 6 for TCP. It
+// returns nil if the number is not in Protocols.
+func ServicesForIPProtocol(number int) []*Servent {
+	protoent := GetProtoByNumber(number)
+	if protoent == nil {
+		return nil
+	}
+	return SubsetByProtocol(protoent)
+}
+
+// A PortRange is an inclusive range of ports.
+type PortRange struct {
+	From, To int
+}
+
+// Contains reports whether port is within the range.
+func (this PortRange) Contains(port int) bool {
+	return port >= this.From && port <= this.To
+}
+
+// SubsetByPortRange returns all entries in Services whose port is
+// within r.
+func SubsetByPortRange(r PortRange) []*Servent {
+	var out []*Servent
+	for _, servent := range Services {
+		if r.Contains(servent.Port) {
+			out = append(out, servent)
+		}
+	}
+	return out
+}
+
+// FilterWellKnown returns all entries in Services whose port is in the
+// well-known range [0, 1023].
+func FilterWellKnown() []*Servent {
+	return SubsetByPortRange(PortRange{From: MinServicePort, To: 1023})
+}
+
+// FilterRegistered returns all entries in Services whose port is in
+// the registered range [1024, 49151].
+func FilterRegistered() []*Servent {
+	return SubsetByPortRange(PortRange{From: 1024, To: 49151})
+}
+
+// FilterDynamic returns all entries in Services whose port is in the
+// dynamic range [49152, 65535].
+func FilterDynamic() []*Servent {
+	return SubsetByPortRange(PortRange{From: 49152, To: MaxServicePort})
+}
+
+// TopNLowestPorts returns up to n services of the protocol with the
+// lowest ports, in ascending order of port. If the protocol is nil,
+// services of all protocols are considered. Services on the same port
+// keep their order in Services.
+func TopNLowestPorts(n int, protocol *Protoent) []*Servent {
+	return topNPorts(n, protocol, func(a, b int) bool { return a < b })
+}
+
+// TopNHighestPorts returns up to n services of the protocol with the
+// highest ports, in descending order of port. If the protocol is nil,
+// services of all protocols are considered. Services on the same port
+// keep their order in Services.
+func TopNHighestPorts(n int, protocol *Protoent) []*Servent {
+	return topNPorts(n, protocol, func(a, b int) bool { return a > b })
+}
+
+func topNPorts(n int, protocol *Protoent, less func(a, b int) bool) []*Servent {
+	if n <= 0 {
+		return nil
+	}
+
+	var out []*Servent
+	for _, servent := range Services {
+		if servent.hasProtocol(protocol) {
+			out = append(out, servent)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return less(out[i].Port, out[j].Port)
+	})
+
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// MatchServices returns all services of the protocol whose name
+// matches pattern, sorted by name. Patterns use the syntax of
+// path.Match, so that "ftp*" matches ftp, ftps and ftp-data. If the
+// protocol is nil, services of all protocols are considered. The only
+// possible error is path.ErrBadPattern.
+func MatchServices(pattern string, protocol *Protoent) ([]*Servent, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	var out []*Servent
+	for _, servent := range Services {
+		if ok, _ := path.Match(pattern, servent.Name); ok && servent.hasProtocol(protocol) {
+			out = append(out, servent)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// MatchProtocols returns all protocols whose name or any of its
+// aliases matches pattern, sorted by name. Patterns use the syntax of
+// path.Match. The only possible error is path.ErrBadPattern.
+func MatchProtocols(pattern string) ([]*Protoent, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	var out []*Protoent
+	for _, protoent := range Protocols {
+		for _, name := range protoent.names() {
+			if ok, _ := path.Match(pattern, name); ok {
+				out = append(out, protoent)
+				break
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
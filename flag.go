@@ -0,0 +1,79 @@
+package netdb
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+var (
+	_ flag.Value = (*Protoent)(nil)
+	_ flag.Value = (*Servent)(nil)
+)
+
+// String returns the protocol's name.
+func (this *Protoent) String() string {
+	if this == nil {
+		return ""
+	}
+	return this.Name
+}
+
+// Set implements flag.Value. It sets the protocol to the entry in
+// Protocols matching s, which may be a protocol number, name or
+// alias.
+func (this *Protoent) Set(s string) error {
+	protoent := LookupProtocol(s)
+	if protoent == nil {
+		return fmt.Errorf("%w: protocol %s", ErrNotFound, s)
+	}
+
+	*this = *protoent
+	return nil
+}
+
+// Type returns "protocol". Together with String and Set, it
+// implements the Value interface of github.com/spf13/pflag.
+func (this *Protoent) Type() string {
+	return "protocol"
+}
+
+// String returns the service's name and protocol in the form
+// "http/tcp", which Set accepts.
+func (this *Servent) String() string {
+	if this == nil {
+		return ""
+	}
+	if this.Protocol == nil {
+		return this.Name
+	}
+	return this.Name + "/" + this.Protocol.Name
+}
+
+// Set implements flag.Value. It sets the service to the entry in
+// Services matching s, which must be in the form name/protocol or
+// port/protocol, such as "http/tcp" or "80/tcp".
+func (this *Servent) Set(s string) error {
+	i := strings.LastIndexByte(s, '/')
+	if i < 0 {
+		return fmt.Errorf("netdb: invalid service %q, want name/protocol or port/protocol", s)
+	}
+
+	protocol := GetProtoByName(s[i+1:])
+	if protocol == nil {
+		return fmt.Errorf("%w: protocol %s", ErrNotFound, s[i+1:])
+	}
+	servent := LookupService(s[:i], protocol)
+	if servent == nil {
+		return fmt.Errorf("%w: service %s", ErrNotFound, s)
+	}
+
+	*this = *servent
+	return nil
+}
+
+// Type returns "service". Together with String and Set, it implements
+// the Value interface of github.com/spf13/pflag.
+func (this *Servent) Type() string {
+	return "service"
+}
@@ -0,0 +1,280 @@
+package netdb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+var (
+	_ fmt.Formatter  = (*Protoent)(nil)
+	_ fmt.Formatter  = (*Servent)(nil)
+	_ fmt.GoStringer = (*Protoent)(nil)
+	_ fmt.GoStringer = (*Servent)(nil)
+)
+
+// Format implements fmt.Formatter. The verbs are:
+//
+//	%v   name and number, as in "tcp(6)"
+//	%+v  the entry as a line of /etc/protocols, as in "tcp 6 TCP"
+//	%#v  Go syntax, as returned by GoString
+//	%s   name, as returned by String
+//	%q   quoted name
+//	%d   number
+//
+// Width, precision and flags apply as they would to a string or, for
+// %d, an integer.
+func (this *Protoent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, this.GoString())
+		return
+	}
+	if this == nil {
+		fmt.Fprintf(f, fmt.FormatString(f, 'v'), nil)
+		return
+	}
+
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('+'):
+			fmt.Fprintf(f, fmt.FormatString(f, verb), strings.Join(append([]string{this.Name, strconv.Itoa(this.Number)}, this.Aliases...), " "))
+		default:
+			fmt.Fprintf(f, fmt.FormatString(f, verb), this.Name+"("+strconv.Itoa(this.Number)+")")
+		}
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), this.Name)
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), this.Number)
+	default:
+		fmt.Fprintf(f, "%%!%c(*netdb.Protoent=%s)", verb, this.Name)
+	}
+}
+
+// GoString returns the protocol in Go syntax, as used by %#v.
+func (this *Protoent) GoString() string {
+	if this == nil {
+		return "(*netdb.Protoent)(nil)"
+	}
+	return fmt.Sprintf("&netdb.Protoent{Name:%#v, Aliases:%#v, Number:%d}", this.Name, this.Aliases, this.Number)
+}
+
+// Format implements fmt.Formatter. The verbs are:
+//
+//	%v   name, port and protocol, as in "http(80/tcp)"
+//	%+v  the entry as a line of /etc/services, as in "http 80/tcp www"
+//	%#v  Go syntax, as returned by GoString
+//	%s   name; unlike String, without the protocol
+//	%q   quoted name
+//	%d   port
+//
+// Width, precision and flags apply as they would to a string or, for
+// %d, an integer.
+func (this *Servent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, this.GoString())
+		return
+	}
+	if this == nil {
+		fmt.Fprintf(f, fmt.FormatString(f, 'v'), nil)
+		return
+	}
+
+	portproto := strconv.Itoa(this.Port)
+	if this.Protocol != nil {
+		portproto += "/" + this.Protocol.Name
+	}
+
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('+'):
+			fmt.Fprintf(f, fmt.FormatString(f, verb), strings.Join(append([]string{this.Name, portproto}, this.Aliases...), " "))
+		default:
+			fmt.Fprintf(f, fmt.FormatString(f, verb), this.Name+"("+portproto+")")
+		}
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), this.Name)
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), this.Port)
+	default:
+		fmt.Fprintf(f, "%%!%c(*netdb.Servent=%s)", verb, this.Name)
+	}
+}
+
+// GoString returns the service in Go syntax, as used by %#v. The
+// protocol is included as a nested Protoent rather than as a pointer
+// value.
+func (this *Servent) GoString() string {
+	if this == nil {
+		return "(*netdb.Servent)(nil)"
+	}
+	return fmt.Sprintf("&netdb.Servent{Name:%#v, Aliases:%#v, Port:%d, Protocol:%s}",
+		this.Name, this.Aliases, this.Port, this.Protocol.GoString())
+}
+
+// FormatService executes the text/template format for the service
+// returned by GetServByPort. The template's data has the fields
+// Name, Port, Protocol (the protocol's name) and Aliases. If no
+// service matches, an error wrapping ErrNotFound is returned.
+func FormatService(port int, protocol *Protoent, format string) (string, error) {
+	servent := GetServByPort(port, protocol)
+	if servent == nil {
+		return "", fmt.Errorf("%w: service on port %d", ErrNotFound, port)
+	}
+
+	data := struct {
+		Name     string
+		Port     int
+		Protocol string
+		Aliases  []string
+	}{servent.Name, servent.Port, servent.key().Proto, servent.Aliases}
+	return executeTemplate(format, data)
+}
+
+// FormatProtocol executes the text/template format for the protocol
+// with the given number. The template's data has the fields Name,
+// Number and Aliases. If no protocol matches, an error wrapping
+// ErrNotFound is returned.
+func FormatProtocol(number int, format string) (string, error) {
+	protoent := GetProtoByNumber(number)
+	if protoent == nil {
+		return "", fmt.Errorf("%w: protocol %d", ErrNotFound, number)
+	}
+
+	data := struct {
+		Name    string
+		Number  int
+		Aliases []string
+	}{protoent.Name, protoent.Number, protoent.Aliases}
+	return executeTemplate(format, data)
+}
+
+func executeTemplate(format string, data interface{}) (string, error) {
+	tmpl, err := template.New("netdb").Parse(format)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DebugDump writes every entry in Protocols and Services to w, one
+// per line. Protocols are sorted by number, services by protocol,
+// port and name, so the output is stable.
+func DebugDump(w io.Writer) error {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 8, 1, ' ', 0)
+
+	for _, protoent := range sortedProtocols() {
+		fmt.Fprintf(tw, "protocol\t%d\t%s\t%s\n",
+			protoent.Number, protoent.Name, strings.Join(protoent.Aliases, " "))
+	}
+	for _, servent := range sortedServices() {
+		key := servent.key()
+		fmt.Fprintf(tw, "service\t%d/%s\t%s\t%s\n",
+			key.Port, key.Proto, servent.Name, strings.Join(servent.Aliases, " "))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return writeTrimmed(w, buf.Bytes())
+}
+
+// ServiceTable returns a table of all services of the protocol, with
+// the columns Name, Port, Protocol and Aliases. If the protocol is
+// nil, services of all protocols are included. Services are ordered
+// as in DebugDump.
+func ServiceTable(protocol *Protoent) string {
+	return renderTable(func(w io.Writer) {
+		fmt.Fprintln(w, "Name\tPort\tProtocol\tAliases")
+		for _, servent := range sortedServices() {
+			if !servent.hasProtocol(protocol) {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n",
+				servent.Name, servent.Port, servent.key().Proto, strings.Join(servent.Aliases, " "))
+		}
+	})
+}
+
+// ProtocolTable returns a table of all protocols, with the columns
+// Name, Number and Aliases. Protocols are ordered as in DebugDump.
+func ProtocolTable() string {
+	return renderTable(func(w io.Writer) {
+		fmt.Fprintln(w, "Name\tNumber\tAliases")
+		for _, protoent := range sortedProtocols() {
+			fmt.Fprintf(w, "%s\t%d\t%s\n",
+				protoent.Name, protoent.Number, strings.Join(protoent.Aliases, " "))
+		}
+	})
+}
+
+// renderTable returns the rows written by fn, aligned in columns.
+func renderTable(fn func(w io.Writer)) string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+	fn(tw)
+	tw.Flush()
+
+	var out bytes.Buffer
+	writeTrimmed(&out, buf.Bytes())
+	return out.String()
+}
+
+// writeTrimmed writes data to w with trailing spaces removed from
+// every line. tabwriter pads cells even if all following cells on
+// a line are empty.
+func writeTrimmed(w io.Writer, data []byte) error {
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		trimmed := bytes.TrimRight(line, " \n")
+		if bytes.HasSuffix(line, []byte("\n")) {
+			trimmed = append(trimmed, '\n')
+		}
+		if _, err := w.Write(trimmed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedProtocols returns a copy of Protocols sorted by number and
+// name.
+func sortedProtocols() []*Protoent {
+	protocols := append([]*Protoent(nil), Protocols...)
+	sort.SliceStable(protocols, func(i, j int) bool {
+		a, b := protocols[i], protocols[j]
+		if a.Number != b.Number {
+			return a.Number < b.Number
+		}
+		return a.Name < b.Name
+	})
+	return protocols
+}
+
+// sortedServices returns a copy of Services sorted by protocol name,
+// port and name.
+func sortedServices() []*Servent {
+	services := append([]*Servent(nil), Services...)
+	sort.SliceStable(services, func(i, j int) bool {
+		a, b := services[i].key(), services[j].key()
+		if a.Proto != b.Proto {
+			return a.Proto < b.Proto
+		}
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		return services[i].Name < services[j].Name
+	})
+	return services
+}
@@ -0,0 +1,63 @@
+package netdb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+func TestFormat(t *testing.T) {
+	tcp := &netdb.Protoent{Name: "tcp", Aliases: []string{"TCP"}, Number: 6}
+	http := &netdb.Servent{Name: "http", Aliases: []string{"www"}, Port: 80, Protocol: tcp}
+
+	tests := []struct {
+		format string
+		arg    interface{}
+		want   string
+	}{
+		{"%v", tcp, "tcp(6)"},
+		{"%+v", tcp, "tcp 6 TCP"},
+		{"%s", tcp, "tcp"},
+		{"%q", tcp, `"tcp"`},
+		{"%d", tcp, "6"},
+		{"[%-8s]", tcp, "[tcp     ]"},
+		{"[%8v]", tcp, "[  tcp(6)]"},
+		{"[%03d]", tcp, "[006]"},
+		{"%.2s", tcp, "tc"},
+		{"%#v", tcp, `&netdb.Protoent{Name:"tcp", Aliases:[]string{"TCP"}, Number:6}`},
+
+		{"%v", http, "http(80/tcp)"},
+		{"%v", &netdb.Servent{Name: "http", Port: 80}, "http(80)"},
+		{"%+v", http, "http 80/tcp www"},
+		{"%s", http, "http"},
+		{"%q", http, `"http"`},
+		{"%d", http, "80"},
+		{"[%-6s]", http, "[http  ]"},
+		{"[%5d]", http, "[   80]"},
+		{"%#v", http, `&netdb.Servent{Name:"http", Aliases:[]string{"www"}, Port:80, Protocol:&netdb.Protoent{Name:"tcp", Aliases:[]string{"TCP"}, Number:6}}`},
+
+		{"%v", (*netdb.Protoent)(nil), "<nil>"},
+		{"%#v", (*netdb.Servent)(nil), "(*netdb.Servent)(nil)"},
+		{"%x", tcp, "%!x(*netdb.Protoent=tcp)"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.arg); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestFormatService(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	got, err := netdb.FormatService(80, nil, "{{.Name}} {{.Port}}/{{.Protocol}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "http 80/tcp"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
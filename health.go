@@ -0,0 +1,191 @@
+package netdb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// criticalServices are the services checked by Preload.
+var criticalServices = []struct {
+	name  string
+	port  int
+	proto string
+}{
+	{"http", 80, "tcp"},
+	{"https", 443, "tcp"},
+	{"ssh", 22, "tcp"},
+	{"domain", 53, "udp"},
+	{"smtp", 25, "tcp"},
+}
+
+// MissingServicesError is returned by Preload and lists the critical
+// services that are missing, in the form "http (80/tcp)".
+type MissingServicesError struct {
+	Missing []string
+}
+
+func (err *MissingServicesError) Error() string {
+	return "netdb: missing critical services: " + strings.Join(err.Missing, ", ")
+}
+
+// Preload checks that Services has entries for HTTP (80/tcp), HTTPS
+// (443/tcp), SSH (22/tcp), DNS (53/udp) and SMTP (25/tcp). If any of
+// them is missing, a *MissingServicesError is returned.
+func Preload() error {
+	var missing []string
+	for _, s := range criticalServices {
+		protocol := GetProtoByName(s.proto)
+		if protocol == nil || GetServByPort(s.port, protocol) == nil {
+			missing = append(missing, s.name+" ("+strconv.Itoa(s.port)+"/"+s.proto+")")
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingServicesError{Missing: missing}
+	}
+	return nil
+}
+
+// ErrDatabaseNotLoaded is returned by EnsureLoaded if Protocols or
+// Services is empty.
+var ErrDatabaseNotLoaded = errors.New("netdb: database not loaded")
+
+// EnsureLoaded returns the error of the last load attempt, if it
+// failed. Otherwise it returns ErrDatabaseNotLoaded if Protocols or
+// Services is empty and nil if both have entries. Load attempts are
+// the loading of the system's databases at initialization and calls
+// to LoadProtocolsFromString and LoadServicesFromString. Assigning
+// to Protocols or Services directly does not count as a load, so it
+// doesn't clear a previous error.
+func EnsureLoaded() error {
+	if loadErr != nil {
+		return loadErr
+	}
+	if len(Protocols) == 0 || len(Services) == 0 {
+		return ErrDatabaseNotLoaded
+	}
+	return nil
+}
+
+// LastLoadTime returns the time of the last successful load attempt,
+// as described for EnsureLoaded. It returns the zero time if no load
+// has succeeded.
+func LastLoadTime() time.Time {
+	return loadTime
+}
+
+// LastLoadError returns the error of the last load attempt, as
+// described for EnsureLoaded, or nil if it succeeded.
+func LastLoadError() error {
+	return loadErr
+}
+
+// ProtocolCount returns the number of entries in Protocols.
+func ProtocolCount() int {
+	return len(Protocols)
+}
+
+// ServiceCount returns the number of entries in Services.
+func ServiceCount() int {
+	return len(Services)
+}
+
+// ProtocolAliasCount returns the total number of aliases of all
+// entries in Protocols.
+func ProtocolAliasCount() int {
+	n := 0
+	for _, protoent := range Protocols {
+		n += len(protoent.Aliases)
+	}
+	return n
+}
+
+// ServiceAliasCount returns the total number of aliases of all
+// entries in Services.
+func ServiceAliasCount() int {
+	n := 0
+	for _, servent := range Services {
+		n += len(servent.Aliases)
+	}
+	return n
+}
+
+// IsEmpty reports whether both Protocols and Services are empty.
+func IsEmpty() bool {
+	return len(Protocols) == 0 && len(Services) == 0
+}
+
+// Summary returns a human-readable overview of the databases, for
+// example for a health check endpoint. It lists the number of
+// protocols and services, the number of services and their port range
+// per protocol, when the databases were last loaded and, for those
+// loaded from the system's files, which files were used. The format
+// is meant for people and may change.
+func Summary() string {
+	type stats struct {
+		count    int
+		min, max int
+	}
+
+	byProto := make(map[string]*stats)
+	for _, servent := range Services {
+		proto := servent.key().Proto
+		st, ok := byProto[proto]
+		if !ok {
+			st = &stats{min: servent.Port, max: servent.Port}
+			byProto[proto] = st
+		}
+		st.count++
+		if servent.Port < st.min {
+			st.min = servent.Port
+		}
+		if servent.Port > st.max {
+			st.max = servent.Port
+		}
+	}
+
+	protos := make([]string, 0, len(byProto))
+	for proto := range byProto {
+		protos = append(protos, proto)
+	}
+	sort.Strings(protos)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Protocols: %d\n", len(Protocols))
+	fmt.Fprintf(&b, "Services:  %d\n", len(Services))
+
+	if len(protos) > 0 {
+		b.WriteString("\nServices by protocol:\n")
+		b.WriteString(renderTable(func(w io.Writer) {
+			for _, proto := range protos {
+				st := byProto[proto]
+				if proto == "" {
+					proto = "(none)"
+				}
+				fmt.Fprintf(w, "  %s\t%d\tports %d-%d\n", proto, st.count, st.min, st.max)
+			}
+		}))
+	}
+
+	b.WriteString("\n")
+	switch {
+	case loadErr != nil:
+		fmt.Fprintf(&b, "Load error: %v\n", loadErr)
+	case loadTime.IsZero():
+		b.WriteString("Loaded: never\n")
+	default:
+		fmt.Fprintf(&b, "Loaded: %s\n", loadTime.Format(time.RFC3339))
+	}
+	if protocolsFile != "" {
+		fmt.Fprintf(&b, "Protocols file: %s\n", protocolsFile)
+	}
+	if servicesFile != "" {
+		fmt.Fprintf(&b, "Services file:  %s\n", servicesFile)
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package netdb
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// A Hostent is an entry of /etc/hosts. Zone is the IPv6 scoped
+// addressing zone of Addr, such as "lo0" in "fe80::1%lo0", and is
+// empty if there is none.
+type Hostent struct {
+	Name    string
+	Aliases []string
+	Addr    net.IP
+	Zone    string
+}
+
+// A ParseError describes a malformed line.
+type ParseError struct {
+	Line string
+	Err  error
+}
+
+func (err *ParseError) Error() string {
+	return "netdb: invalid line " + strconv.Quote(err.Line) + ": " + err.Err.Error()
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+// ParseHostLine parses a single line in the format of /etc/hosts. The
+// address may be IPv4 or IPv6, and IPv6 addresses may have a zone, as
+// in "fe80::1%lo0". A line that lacks a host name or has
+// an invalid address results in a *ParseError. If the line doesn't
+// contain an entry, for example because it is empty or only holds a
+// comment, nil is returned.
+func ParseHostLine(line string) (*Hostent, error) {
+	split := strings.SplitN(line, "#", 2)
+	fields := strings.Fields(split[0])
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if len(fields) < 2 {
+		return nil, &ParseError{Line: line, Err: errors.New("missing host name")}
+	}
+
+	addr, zone, err := parseHost(fields[0])
+	if err != nil || (zone != "" && addr.To4() != nil) || strings.HasSuffix(fields[0], "%") {
+		return nil, &ParseError{Line: line, Err: errors.New("invalid address " + strconv.Quote(fields[0]))}
+	}
+
+	return &Hostent{
+		Name:    fields[1],
+		Aliases: fields[2:],
+		Addr:    addr,
+		Zone:    zone,
+	}, nil
+}
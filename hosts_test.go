@@ -0,0 +1,44 @@
+package netdb_test
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"honnef.co/go/netdb"
+)
+
+func TestParseHostLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want *netdb.Hostent
+	}{
+		{"127.0.0.1 localhost", &netdb.Hostent{Name: "localhost", Aliases: []string{}, Addr: net.ParseIP("127.0.0.1")}},
+		{"::1\tlocalhost ip6-localhost ip6-loopback", &netdb.Hostent{Name: "localhost", Aliases: []string{"ip6-localhost", "ip6-loopback"}, Addr: net.ParseIP("::1")}},
+		{"fe80::1%lo0 localhost # scoped", &netdb.Hostent{Name: "localhost", Aliases: []string{}, Addr: net.ParseIP("fe80::1"), Zone: "lo0"}},
+		{"# comment", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := netdb.ParseHostLine(tt.line)
+		if err != nil {
+			t.Errorf("%q: %v", tt.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseHostLineErrors(t *testing.T) {
+	for _, line := range []string{"127.0.0.1", "localhost 127.0.0.1", "127.0.0.1%eth0 localhost", "fe80::1% localhost"} {
+		_, err := netdb.ParseHostLine(line)
+		var parseErr *netdb.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: got error %v, want a *ParseError", line, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package netdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler returns an http.Handler that serves Protocols and Services
+// as JSON. The following routes are provided:
+//
+//	GET /protocols               all protocols
+//	GET /protocols/{number}      the protocol with the given number
+//	GET /protocols/name/{name}   the protocol with the given name or alias
+//	GET /services                all services
+//	GET /services/port/{port}    all services on the given port
+//	GET /services/name/{name}    all services with the given name or alias
+//
+// Requests for unknown routes or entries result in a 404.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/protocols", serveProtocols)
+	mux.HandleFunc("/protocols/", serveProtocols)
+	mux.HandleFunc("/services", serveServices)
+	mux.HandleFunc("/services/", serveServices)
+	return mux
+}
+
+func serveProtocols(w http.ResponseWriter, r *http.Request) {
+	if !checkMethod(w, r) {
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/protocols")
+	switch {
+	case path == "" || path == "/":
+		writeJSON(w, Protocols)
+	case strings.HasPrefix(path, "/name/"):
+		writeProtoent(w, r, GetProtoByName(strings.TrimPrefix(path, "/name/")))
+	default:
+		num, err := strconv.Atoi(strings.TrimPrefix(path, "/"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		writeProtoent(w, r, GetProtoByNumber(num))
+	}
+}
+
+func serveServices(w http.ResponseWriter, r *http.Request) {
+	if !checkMethod(w, r) {
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/services")
+	switch {
+	case path == "" || path == "/":
+		writeServents(w, r, Services, true)
+	case strings.HasPrefix(path, "/port/"):
+		port, err := strconv.Atoi(strings.TrimPrefix(path, "/port/"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		var servents []*Servent
+		for _, servent := range Services {
+			if servent.Port == port {
+				servents = append(servents, servent)
+			}
+		}
+		writeServents(w, r, servents, false)
+	case strings.HasPrefix(path, "/name/"):
+		name := strings.TrimPrefix(path, "/name/")
+
+		var servents []*Servent
+		for _, servent := range Services {
+			if servent.hasName(name) {
+				servents = append(servents, servent)
+			}
+		}
+		writeServents(w, r, servents, false)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func checkMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func writeProtoent(w http.ResponseWriter, r *http.Request, protoent *Protoent) {
+	if protoent == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, protoent)
+}
+
+func writeServents(w http.ResponseWriter, r *http.Request, servents []*Servent, all bool) {
+	if len(servents) == 0 && !all {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, servents)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// FetchHTTP retrieves protocols and services from baseURL, which is
+// expected to be served by Handler. The Protocol of each returned
+// Servent points into the returned list of protocols. If client is
+// nil, http.DefaultClient is used.
+//
+// The result can be assigned to Protocols and Services to replace the
+// data loaded from the local system.
+func FetchHTTP(ctx context.Context, client *http.Client, baseURL string) (protocols []*Protoent, services []*Servent, err error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	if err := fetchJSON(ctx, client, baseURL+"/protocols", &protocols); err != nil {
+		return nil, nil, err
+	}
+	if err := fetchJSON(ctx, client, baseURL+"/services", &services); err != nil {
+		return nil, nil, err
+	}
+
+	byNumber := make(map[int]*Protoent, len(protocols))
+	for _, protoent := range protocols {
+		byNumber[protoent.Number] = protoent
+	}
+	for _, servent := range services {
+		if servent.Protocol != nil {
+			servent.Protocol = byNumber[servent.Protocol.Number]
+		}
+	}
+
+	return protocols, services, nil
+}
+
+func fetchJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("netdb: fetching %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,44 @@
+package netdb_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+func TestFetchHTTP(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	srv := httptest.NewServer(netdb.Handler())
+	defer srv.Close()
+
+	protocols, services, err := netdb.FetchHTTP(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(protocols, netdb.Protocols) {
+		t.Errorf("fetched protocols differ from Protocols")
+	}
+	if !reflect.DeepEqual(services, netdb.Services) {
+		t.Errorf("fetched services differ from Services")
+	}
+	for i, servent := range services {
+		j := indexOf(netdb.Protocols, netdb.Services[i].Protocol)
+		if servent.Protocol != protocols[j] {
+			t.Errorf("%s: Protocol does not point into the fetched protocols", servent.Name)
+		}
+	}
+}
+
+func indexOf(protocols []*netdb.Protoent, protocol *netdb.Protoent) int {
+	for i, protoent := range protocols {
+		if protoent == protocol {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,23 @@
+package netdb
+
+import "strings"
+
+// URLs of the IANA registries that /etc/protocols and /etc/services
+// are derived from.
+const (
+	IANAProtocolsURL = "https://www.iana.org/assignments/protocol-numbers/protocol-numbers.xhtml"
+	IANAServicesURL  = "https://www.iana.org/assignments/service-names-port-numbers/service-names-port-numbers.xhtml"
+)
+
+// ProtocolByIANAKeyword returns the Protoent whose name matches an
+// IANA protocol keyword, such as "ICMP" or "IPv6-ICMP". Names are
+// compared case-insensitively, as /etc/protocols uses lowercase
+// versions of the keywords.
+func ProtocolByIANAKeyword(keyword string) *Protoent {
+	for _, protoent := range Protocols {
+		if strings.EqualFold(protoent.Name, keyword) {
+			return protoent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+//go:build go1.23
+
+package netdb
+
+import "iter"
+
+// ProtocolsSeq returns an iterator over all entries in Protocols.
+func ProtocolsSeq() iter.Seq[*Protoent] {
+	return func(yield func(*Protoent) bool) {
+		for _, protoent := range Protocols {
+			if !yield(protoent) {
+				return
+			}
+		}
+	}
+}
+
+// ServicesSeq returns an iterator over all entries in Services.
+func ServicesSeq() iter.Seq[*Servent] {
+	return func(yield func(*Servent) bool) {
+		for _, servent := range Services {
+			if !yield(servent) {
+				return
+			}
+		}
+	}
+}
+
+// ProtocolsSeq2 returns an iterator over all entries in Protocols,
+// yielding each entry together with its index.
+func ProtocolsSeq2() iter.Seq2[int, *Protoent] {
+	return func(yield func(int, *Protoent) bool) {
+		for i, protoent := range Protocols {
+			if !yield(i, protoent) {
+				return
+			}
+		}
+	}
+}
+
+// ServicesSeq2 returns an iterator over all entries in Services,
+// yielding each entry together with its index.
+func ServicesSeq2() iter.Seq2[int, *Servent] {
+	return func(yield func(int, *Servent) bool) {
+		for i, servent := range Services {
+			if !yield(i, servent) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package netdb
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// resetLoadState clears the databases and the load state, restoring
+// them when the test finishes.
+func resetLoadState(t testing.TB) {
+	oldProtocols, oldServices := Protocols, Services
+	oldErr, oldTime := loadErr, loadTime
+	oldProtocolsFile, oldServicesFile := protocolsFile, servicesFile
+	Protocols, Services = nil, nil
+	loadErr, loadTime = nil, time.Time{}
+	protocolsFile, servicesFile = "", ""
+	t.Cleanup(func() {
+		Protocols, Services = oldProtocols, oldServices
+		loadErr, loadTime = oldErr, oldTime
+		protocolsFile, servicesFile = oldProtocolsFile, oldServicesFile
+	})
+}
+
+func TestLoadMissingFiles(t *testing.T) {
+	resetLoadState(t)
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	dir := t.TempDir()
+	load(filepath.Join(dir, "protocols"), filepath.Join(dir, "services"))
+
+	if err := EnsureLoaded(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("EnsureLoaded() = %v, want an error wrapping os.ErrNotExist", err)
+	}
+	if !LastLoadTime().IsZero() {
+		t.Errorf("LastLoadTime() = %v, want the zero time", LastLoadTime())
+	}
+	if protoent := GetProtoByName("tcp"); protoent != nil {
+		t.Errorf("GetProtoByName(\"tcp\") = %v, want nil", protoent)
+	}
+	if servent := GetServByPort(80, nil); servent != nil {
+		t.Errorf("GetServByPort(80, nil) = %v, want nil", servent)
+	}
+	if servent := LookupService("http", nil); servent != nil {
+		t.Errorf("LookupService(\"http\", nil) = %v, want nil", servent)
+	}
+}
+
+func TestLoadMissingServices(t *testing.T) {
+	resetLoadState(t)
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	dir := t.TempDir()
+	protoFile := filepath.Join(dir, "protocols")
+	if err := ioutil.WriteFile(protoFile, []byte("tcp 6 TCP\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	load(protoFile, filepath.Join(dir, "services"))
+
+	if err := EnsureLoaded(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("EnsureLoaded() = %v, want an error wrapping os.ErrNotExist", err)
+	}
+	if len(Protocols) != 0 {
+		t.Errorf("got %d protocols, want none after a failed load", len(Protocols))
+	}
+}
+
+func TestLoadParseError(t *testing.T) {
+	resetLoadState(t)
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	dir := t.TempDir()
+	protoFile := filepath.Join(dir, "protocols")
+	if err := ioutil.WriteFile(protoFile, []byte("tcp 6 TCP\nudp x UDP\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	load(protoFile, filepath.Join(dir, "services"))
+
+	err := EnsureLoaded()
+	if err == nil {
+		t.Fatal("EnsureLoaded() = nil, want a parse error")
+	}
+	want := "netdb: could not load " + protoFile + ": line 2: "
+	if msg := err.Error(); !strings.HasPrefix(msg, want) || strings.Count(msg, "netdb:") != 1 {
+		t.Errorf("EnsureLoaded() = %q, want a single prefix %q", msg, want)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("EnsureLoaded() = %v, want an error wrapping *strconv.NumError", err)
+	}
+}
+
+func TestLoadServicesResolvesAliases(t *testing.T) {
+	protocols, err := ParseProtocolsBytes([]byte("tcp 6 TCP\nudp 17 UDP\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	services, err := loadServices([]byte("http 80/tcp\ndomain 53/UDP\n"), protocols)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []*Protoent{protocols[0], protocols[1]} {
+		if services[i].Protocol != want {
+			t.Errorf("%s: got protocol %v, want %v", services[i].Name, services[i].Protocol, want)
+		}
+	}
+}
+
+// BenchmarkLoad compares load, which reads both files concurrently,
+// with reading them one after the other. Every read is delayed by a
+// millisecond to simulate slow I/O.
+func BenchmarkLoad(b *testing.B) {
+	resetLoadState(b)
+
+	dir := b.TempDir()
+	protoFile := filepath.Join(dir, "protocols")
+	servFile := filepath.Join(dir, "services")
+	if err := ioutil.WriteFile(protoFile, []byte("tcp 6 TCP\nudp 17 UDP\n"), 0644); err != nil {
+		b.Fatal(err)
+	}
+	if err := ioutil.WriteFile(servFile, []byte("http 80/tcp www\ndomain 53/udp\n"), 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	oldReadFile := readFile
+	readFile = func(file string) ([]byte, error) {
+		time.Sleep(time.Millisecond)
+		return oldReadFile(file)
+	}
+	b.Cleanup(func() { readFile = oldReadFile })
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			protoData, err := readFile(protoFile)
+			if err != nil {
+				b.Fatal(err)
+			}
+			servData, err := readFile(servFile)
+			if err != nil {
+				b.Fatal(err)
+			}
+			protocols, err := ParseProtocolsBytes(protoData)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := loadServices(servData, protocols); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("concurrent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			load(protoFile, servFile)
+			if loadErr != nil {
+				b.Fatal(loadErr)
+			}
+		}
+	})
+}
+
+const protocolsData = `# Internet protocols
+
+ip	0	IP		# internet protocol
+   # indented comment
+icmp	1
+tcp	6	TCP Tcp tcp-proto TRANSMISSION-CONTROL
+
+udp	17	UDP
+`
+
+const servicesData = `# Network services
+
+ftp		21/tcp
+ssh		22/tcp				# SSH Remote Login Protocol
+	# indented comment
+http		80/tcp		www www-http World-Wide-Web HTTP
+domain		53/udp
+`
+
+func TestLoadProtocolsFromString(t *testing.T) {
+	resetLoadState(t)
+
+	if err := LoadProtocolsFromString(protocolsData); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Protoent{
+		{Name: "ip", Aliases: []string{"IP"}, Number: 0},
+		{Name: "icmp", Aliases: []string{}, Number: 1},
+		{Name: "tcp", Aliases: []string{"TCP", "Tcp", "tcp-proto", "TRANSMISSION-CONTROL"}, Number: 6},
+		{Name: "udp", Aliases: []string{"UDP"}, Number: 17},
+	}
+	if !reflect.DeepEqual(Protocols, want) {
+		t.Errorf("got %+v, want %+v", Protocols, want)
+	}
+}
+
+func TestLoadServicesFromString(t *testing.T) {
+	resetLoadState(t)
+	Protocols = []*Protoent{
+		{Name: "tcp", Aliases: []string{"TCP"}, Number: 6},
+		{Name: "udp", Aliases: []string{"UDP"}, Number: 17},
+	}
+
+	if err := LoadServicesFromString(servicesData); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp, udp := GetProtoByName("tcp"), GetProtoByName("udp")
+	want := []*Servent{
+		{Name: "ftp", Aliases: []string{}, Port: 21, Protocol: tcp},
+		{Name: "ssh", Aliases: []string{}, Port: 22, Protocol: tcp},
+		{Name: "http", Aliases: []string{"www", "www-http", "World-Wide-Web", "HTTP"}, Port: 80, Protocol: tcp},
+		{Name: "domain", Aliases: []string{}, Port: 53, Protocol: udp},
+	}
+	if !reflect.DeepEqual(Services, want) {
+		t.Errorf("got %+v, want %+v", Services, want)
+	}
+	for i, servent := range Services {
+		if servent.Protocol != want[i].Protocol {
+			t.Errorf("%s: protocol was not resolved against Protocols", servent.Name)
+		}
+	}
+}
+
+func TestLoadFromStringOnlyComments(t *testing.T) {
+	resetLoadState(t)
+
+	const data = "\n# nothing here\n\n   \n\t# still nothing\n"
+	if err := LoadProtocolsFromString(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadServicesFromString(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(Protocols) != 0 || len(Services) != 0 {
+		t.Errorf("got %d protocols and %d services, want none", len(Protocols), len(Services))
+	}
+}
+
+func TestLoadFromStringError(t *testing.T) {
+	resetLoadState(t)
+	tcp := &Protoent{Name: "tcp", Aliases: []string{"TCP"}, Number: 6}
+	protocols := []*Protoent{tcp}
+	services := []*Servent{{Name: "http", Aliases: []string{}, Port: 80, Protocol: tcp}}
+	Protocols, Services = protocols, services
+
+	if err := LoadProtocolsFromString("tcp six TCP\n"); err == nil {
+		t.Errorf("LoadProtocolsFromString succeeded on malformed data")
+	}
+	if err := LoadServicesFromString("http 80\n"); err == nil {
+		t.Errorf("LoadServicesFromString succeeded on malformed data")
+	}
+	if !reflect.DeepEqual(Protocols, protocols) || !reflect.DeepEqual(Services, services) {
+		t.Errorf("failed loads modified Protocols or Services")
+	}
+}
+
+func TestLoadFromStringClearsLoadError(t *testing.T) {
+	resetLoadState(t)
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	dir := t.TempDir()
+	load(filepath.Join(dir, "protocols"), filepath.Join(dir, "services"))
+	if EnsureLoaded() == nil {
+		t.Fatal("EnsureLoaded succeeded after loading missing files")
+	}
+
+	if err := LoadProtocolsFromString("tcp 6 TCP\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadServicesFromString("http 80/tcp\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := EnsureLoaded(); err != nil {
+		t.Errorf("EnsureLoaded() = %v, want nil", err)
+	}
+	if LastLoadTime().IsZero() {
+		t.Errorf("LastLoadTime() is zero after a successful load")
+	}
+
+	if err := LoadServicesFromString("http 80\n"); err == nil {
+		t.Fatal("LoadServicesFromString succeeded on malformed data")
+	}
+	if err := LastLoadError(); err == nil {
+		t.Errorf("LastLoadError() = nil after a failed load")
+	}
+}
+
+func TestSummaryLoadSource(t *testing.T) {
+	resetLoadState(t)
+
+	summary := Summary()
+	if !strings.Contains(summary, "Loaded: never\n") || strings.Contains(summary, " file:") {
+		t.Errorf("Summary before loading:\n%s", summary)
+	}
+
+	dir := t.TempDir()
+	protoFile := filepath.Join(dir, "protocols")
+	servFile := filepath.Join(dir, "services")
+	if err := ioutil.WriteFile(protoFile, []byte("tcp 6 TCP\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(servFile, []byte("http 80/tcp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	load(protoFile, servFile)
+	summary = Summary()
+	if !strings.Contains(summary, "Protocols file: "+protoFile+"\n") || !strings.Contains(summary, "Services file:  "+servFile+"\n") {
+		t.Errorf("Summary after load doesn't list the files:\n%s", summary)
+	}
+
+	if err := LoadServicesFromString("ssh 22/tcp\n"); err != nil {
+		t.Fatal(err)
+	}
+	summary = Summary()
+	if !strings.Contains(summary, "Protocols file: "+protoFile+"\n") || strings.Contains(summary, "Services file:") {
+		t.Errorf("Summary after LoadServicesFromString:\n%s", summary)
+	}
+}
@@ -0,0 +1,355 @@
+package netdb
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LookupProtocol returns the Protoent for a protocol number or, if
+// nameOrNumber isn't a number, for a protocol name or alias.
+func LookupProtocol(nameOrNumber string) *Protoent {
+	if num, err := strconv.Atoi(nameOrNumber); err == nil {
+		return GetProtoByNumber(num)
+	}
+	return GetProtoByName(nameOrNumber)
+}
+
+// LookupService returns the Servent for a port number or, if
+// nameOrPort isn't a number, for a service name or alias. If the
+// protocol is nil, the first matching service of any protocol is
+// returned.
+func LookupService(nameOrPort string, protocol *Protoent) *Servent {
+	if port, err := strconv.Atoi(nameOrPort); err == nil {
+		return findService(func(servent *Servent) bool {
+			return servent.Port == port && servent.hasProtocol(protocol)
+		})
+	}
+	return findService(func(servent *Servent) bool {
+		return servent.hasName(nameOrPort) && servent.hasProtocol(protocol)
+	})
+}
+
+// ResolveServicePortOrName returns the port for a port number or, if
+// portOrName isn't a number, the port of the first service of the
+// protocol with that name or alias. If the protocol is nil, services
+// of any protocol are considered. Port numbers outside
+// [MinServicePort, MaxServicePort] are an error, and unknown names
+// result in an error wrapping ErrNotFound. Port numbers are not
+// required to be in Services.
+func ResolveServicePortOrName(portOrName string, protocol *Protoent) (int, error) {
+	if port, err := strconv.Atoi(portOrName); err == nil {
+		if _, err := NewServicePort(port); err != nil {
+			return 0, err
+		}
+		return port, nil
+	}
+
+	servent := findService(func(servent *Servent) bool {
+		return servent.hasName(portOrName) && servent.hasProtocol(protocol)
+	})
+	if servent == nil {
+		return 0, fmt.Errorf("%w: service %s", ErrNotFound, portOrName)
+	}
+	return servent.Port, nil
+}
+
+// ResolveProtocolNumberOrName returns the number for a protocol
+// number or, if protoOrNum isn't a number, the number of the protocol
+// with that name or alias. Numbers outside [0, MaxProtocolNumber] are
+// an error, and unknown names result in an error wrapping
+// ErrNotFound. Numbers are not required to be in Protocols.
+func ResolveProtocolNumberOrName(protoOrNum string) (int, error) {
+	if num, err := strconv.Atoi(protoOrNum); err == nil {
+		if num < 0 || num > MaxProtocolNumber {
+			return 0, fmt.Errorf("netdb: protocol number %d out of range", num)
+		}
+		return num, nil
+	}
+
+	protoent := GetProtoByName(protoOrNum)
+	if protoent == nil {
+		return 0, fmt.Errorf("%w: protocol %s", ErrNotFound, protoOrNum)
+	}
+	return protoent.Number, nil
+}
+
+// LookupAll returns all protocols and services matching s. If s is a
+// number, protocols with that number and services on that port are
+// returned. Otherwise, protocols and services with that name or
+// alias are returned.
+func LookupAll(s string) (protocols []*Protoent, services []*Servent) {
+	num, err := strconv.Atoi(s)
+	isNum := err == nil
+
+	for _, protoent := range Protocols {
+		if (isNum && protoent.Number == num) || (!isNum && protoent.hasName(s)) {
+			protocols = append(protocols, protoent)
+		}
+	}
+	for _, servent := range Services {
+		if (isNum && servent.Port == num) || (!isNum && servent.hasName(s)) {
+			services = append(services, servent)
+		}
+	}
+
+	return protocols, services
+}
+
+// GetProtoByNumberOrDefault is like GetProtoByNumber but returns def
+// if no protocol matches.
+func GetProtoByNumberOrDefault(num int, def *Protoent) *Protoent {
+	if protoent := GetProtoByNumber(num); protoent != nil {
+		return protoent
+	}
+	return def
+}
+
+// GetServByNameOrDefault is like GetServByName but returns def if no
+// service matches.
+func GetServByNameOrDefault(name string, protocol *Protoent, def *Servent) *Servent {
+	if servent := GetServByName(name, protocol); servent != nil {
+		return servent
+	}
+	return def
+}
+
+// GetServByPortOrDefault is like GetServByPort but returns def if no
+// service matches.
+func GetServByPortOrDefault(port int, protocol *Protoent, def *Servent) *Servent {
+	if servent := GetServByPort(port, protocol); servent != nil {
+		return servent
+	}
+	return def
+}
+
+// ServiceAliasesFor returns the aliases of the service returned by
+// GetServByPort, and whether there is such a service.
+func ServiceAliasesFor(port int, protocol *Protoent) ([]string, bool) {
+	servent := GetServByPort(port, protocol)
+	if servent == nil {
+		return nil, false
+	}
+	return servent.Aliases, true
+}
+
+// ProtocolAliasesFor returns the aliases of the protocol with the
+// given number, and whether there is such a protocol.
+func ProtocolAliasesFor(num int) ([]string, bool) {
+	protoent := GetProtoByNumber(num)
+	if protoent == nil {
+		return nil, false
+	}
+	return protoent.Aliases, true
+}
+
+// ServiceCanonicalName returns the name of the service returned by
+// GetServByPort, and whether there is such a service.
+func ServiceCanonicalName(port int, protocol *Protoent) (string, bool) {
+	servent := GetServByPort(port, protocol)
+	if servent == nil {
+		return "", false
+	}
+	return servent.Name, true
+}
+
+// ProtocolCanonicalName returns the name of the protocol with the
+// given number, and whether there is such a protocol.
+func ProtocolCanonicalName(num int) (string, bool) {
+	protoent := GetProtoByNumber(num)
+	if protoent == nil {
+		return "", false
+	}
+	return protoent.Name, true
+}
+
+// ProtocolNumberForName returns the number of the protocol whose
+// name or any of its aliases matches the argument, and whether there
+// is such a protocol.
+func ProtocolNumberForName(name string) (int, bool) {
+	protoent := GetProtoByName(name)
+	if protoent == nil {
+		return 0, false
+	}
+	return protoent.Number, true
+}
+
+// ServicePortForName returns the port of the service returned by
+// GetServByName, and whether there is such a service.
+func ServicePortForName(name string, protocol *Protoent) (int, bool) {
+	servent := GetServByName(name, protocol)
+	if servent == nil {
+		return 0, false
+	}
+	return servent.Port, true
+}
+
+// ProtocolForServicePort returns the distinct protocols of all
+// services on a port, in the order they first appear in Services.
+// For port 53, this usually is TCP and UDP.
+func ProtocolForServicePort(port int) []*Protoent {
+	var protocols []*Protoent
+outer:
+	for _, servent := range Services {
+		if servent.Port != port || servent.Protocol == nil {
+			continue
+		}
+		for _, protoent := range protocols {
+			if protoent.Equal(servent.Protocol) {
+				continue outer
+			}
+		}
+		protocols = append(protocols, servent.Protocol)
+	}
+	return protocols
+}
+
+// ServiceProtocols returns the distinct protocols used by entries in
+// Services, sorted by name. It can be used to check whether, for
+// example, any SCTP services are known.
+func ServiceProtocols() []*Protoent {
+	seen := make(map[int]bool)
+	var protocols []*Protoent
+	for _, servent := range Services {
+		if servent.Protocol == nil || seen[servent.Protocol.Number] {
+			continue
+		}
+		seen[servent.Protocol.Number] = true
+		protocols = append(protocols, servent.Protocol)
+	}
+
+	sort.Slice(protocols, func(i, j int) bool {
+		return protocols[i].Name < protocols[j].Name
+	})
+	return protocols
+}
+
+// A PortSpecError describes a malformed port specification passed to
+// LookupPortByServiceURL.
+type PortSpecError struct {
+	Spec   string
+	Reason string
+}
+
+func (err *PortSpecError) Error() string {
+	return "netdb: invalid port spec " + strconv.Quote(err.Spec) + ": " + err.Reason
+}
+
+// LookupPortByServiceURL returns the service for a port specification
+// in the style of Docker Compose, such as "80", "80/tcp", "http/tcp"
+// or "8080:80/tcp". If the specification maps a host port to a
+// container port, the container port is looked up. The protocol
+// defaults to TCP. Malformed specifications result in a
+// *PortSpecError, unknown services in an error wrapping ErrNotFound.
+func LookupPortByServiceURL(portSpec string) (*Servent, error) {
+	spec := portSpec
+	if i := strings.LastIndexByte(spec, ':'); i >= 0 {
+		spec = spec[i+1:]
+	}
+
+	proto := "tcp"
+	if i := strings.IndexByte(spec, '/'); i >= 0 {
+		spec, proto = spec[:i], spec[i+1:]
+	}
+	if spec == "" || proto == "" || strings.Contains(proto, "/") {
+		return nil, &PortSpecError{Spec: portSpec, Reason: "want port, port/protocol or name/protocol"}
+	}
+	if i := strings.IndexByte(spec, '-'); i >= 0 {
+		if _, err := strconv.Atoi(spec[:i]); err == nil {
+			return nil, &PortSpecError{Spec: portSpec, Reason: "port ranges are not supported"}
+		}
+	}
+
+	protocol := GetProtoByName(proto)
+	if protocol == nil {
+		return nil, &PortSpecError{Spec: portSpec, Reason: "unknown protocol " + strconv.Quote(proto)}
+	}
+
+	servent := LookupService(spec, protocol)
+	if servent == nil {
+		return nil, fmt.Errorf("%w: service %s/%s", ErrNotFound, spec, proto)
+	}
+	return servent, nil
+}
+
+// LookupHostService splits a string of the form "host:service", as
+// used by net.Dial, and looks up the service, which may be a name or
+// a port number. The first matching service of any protocol is
+// returned. Unknown services result in an error wrapping ErrNotFound.
+func LookupHostService(hostservice string) (host string, servent *Servent, err error) {
+	host, service, err := net.SplitHostPort(hostservice)
+	if err != nil {
+		return "", nil, err
+	}
+
+	servent = LookupService(service, nil)
+	if servent == nil {
+		return "", nil, fmt.Errorf("%w: service %s", ErrNotFound, service)
+	}
+	return host, servent, nil
+}
+
+// PortsForService returns the sorted, distinct ports of all services
+// whose name or any of its aliases matches the argument, regardless
+// of protocol.
+func PortsForService(name string) []int {
+	seen := make(map[int]bool)
+	var ports []int
+	for _, servent := range Services {
+		if servent.hasName(name) && !seen[servent.Port] {
+			seen[servent.Port] = true
+			ports = append(ports, servent.Port)
+		}
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// PortsForServiceWithProtocol returns the distinct port and protocol
+// pairs of all services whose name or any of its aliases matches the
+// argument, sorted by port and protocol.
+func PortsForServiceWithProtocol(name string) []PortProtoKey {
+	seen := make(map[PortProtoKey]bool)
+	var keys []PortProtoKey
+	for _, servent := range Services {
+		if key := servent.key(); servent.hasName(name) && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Port != keys[j].Port {
+			return keys[i].Port < keys[j].Port
+		}
+		return keys[i].Proto < keys[j].Proto
+	})
+	return keys
+}
+
+// ServicesByName returns the services whose name or any of its
+// aliases matches the argument, keyed by protocol name. For each
+// protocol, the first matching service is used.
+func ServicesByName(name string) map[string]*Servent {
+	m := make(map[string]*Servent)
+	for _, servent := range Services {
+		if servent.Protocol == nil || !servent.hasName(name) {
+			continue
+		}
+		if _, ok := m[servent.Protocol.Name]; !ok {
+			m[servent.Protocol.Name] = servent
+		}
+	}
+	return m
+}
+
+// findService returns the first service for which match returns true.
+func findService(match func(*Servent) bool) *Servent {
+	for _, servent := range Services {
+		if match(servent) {
+			return servent
+		}
+	}
+	return nil
+}
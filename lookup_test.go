@@ -0,0 +1,84 @@
+package netdb_test
+
+import (
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+func TestGetServNilProtocol(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	if got := netdb.GetServByName("www", nil); got == nil || got.Name != "http" {
+		t.Errorf("GetServByName(\"www\", nil) = %v, want http", got)
+	}
+	if got := netdb.GetServByPort(53, nil); got == nil || got.Protocol.Name != "tcp" {
+		t.Errorf("GetServByPort(53, nil) = %v, want domain(53/tcp)", got)
+	}
+}
+
+func TestOrDefaultNilProtocol(t *testing.T) {
+	netdbtest.UseFixture(t)
+	def := &netdb.Servent{Name: "default"}
+
+	if got := netdb.GetServByNameOrDefault("www", nil, def); got == def || got.Name != "http" {
+		t.Errorf("GetServByNameOrDefault(\"www\", nil) = %v, want http", got)
+	}
+	if got := netdb.GetServByPortOrDefault(443, nil, def); got == def || got.Name != "https" {
+		t.Errorf("GetServByPortOrDefault(443, nil) = %v, want https", got)
+	}
+	udp := netdb.GetProtoByName("udp")
+	if got := netdb.GetServByPortOrDefault(80, udp, def); got != def {
+		t.Errorf("GetServByPortOrDefault(80, udp) = %v, want the default", got)
+	}
+}
+
+func TestServiceAliasesFor(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	aliases, ok := netdb.ServiceAliasesFor(80, nil)
+	if !ok || len(aliases) != 1 || aliases[0] != "www" {
+		t.Errorf("ServiceAliasesFor(80, nil) = %v, %t, want [www], true", aliases, ok)
+	}
+	if _, ok := netdb.ServiceAliasesFor(80, netdb.GetProtoByName("udp")); ok {
+		t.Errorf("ServiceAliasesFor(80, udp) found a service")
+	}
+}
+
+func TestServiceCanonicalName(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	if name, ok := netdb.ServiceCanonicalName(53, nil); !ok || name != "domain" {
+		t.Errorf("ServiceCanonicalName(53, nil) = %q, %t, want domain, true", name, ok)
+	}
+	if _, ok := netdb.ServiceCanonicalName(22, netdb.GetProtoByName("udp")); ok {
+		t.Errorf("ServiceCanonicalName(22, udp) found a service")
+	}
+}
+
+func TestServicePortForName(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	if port, ok := netdb.ServicePortForName("http", nil); !ok || port != 80 {
+		t.Errorf("ServicePortForName(\"http\", nil) = %d, %t, want 80, true", port, ok)
+	}
+	if port, ok := netdb.ServicePortForName("www", netdb.GetProtoByName("tcp")); !ok || port != 80 {
+		t.Errorf("ServicePortForName(\"www\", tcp) = %d, %t, want 80, true", port, ok)
+	}
+	if _, ok := netdb.ServicePortForName("ssh", netdb.GetProtoByName("udp")); ok {
+		t.Errorf("ServicePortForName(\"ssh\", udp) found a service")
+	}
+}
+
+func TestSubsetByProtocol(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	if got := netdb.SubsetByProtocol(nil); len(got) != len(netdb.Services) {
+		t.Errorf("SubsetByProtocol(nil) returned %d services, want %d", len(got), len(netdb.Services))
+	}
+	udp := netdb.SubsetByProtocol(netdb.GetProtoByName("udp"))
+	if len(udp) != 1 || udp[0].Name != "domain" {
+		t.Errorf("SubsetByProtocol(udp) = %v, want [domain(53/udp)]", udp)
+	}
+}
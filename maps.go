@@ -0,0 +1,221 @@
+package netdb
+
+// ToProtoNumberMap returns a map from protocol number to a copy of the
+// corresponding entry in Protocols.
+func ToProtoNumberMap() map[int]Protoent {
+	m := make(map[int]Protoent, len(Protocols))
+	for _, protoent := range Protocols {
+		if _, ok := m[protoent.Number]; !ok {
+			m[protoent.Number] = *protoent.clone()
+		}
+	}
+	return m
+}
+
+// ToProtoNameMap returns a map from canonical protocol name to a copy
+// of the corresponding entry in Protocols.
+func ToProtoNameMap() map[string]Protoent {
+	m := make(map[string]Protoent, len(Protocols))
+	for _, protoent := range Protocols {
+		if _, ok := m[protoent.Name]; !ok {
+			m[protoent.Name] = *protoent.clone()
+		}
+	}
+	return m
+}
+
+// ToServicePortMap returns a map from port number to a copy of the
+// corresponding entry in Services. If the protocol is nil, services
+// of all protocols are included and the first service on a port
+// wins.
+func ToServicePortMap(protocol *Protoent) map[int]Servent {
+	m := make(map[int]Servent)
+	for _, servent := range Services {
+		if !servent.hasProtocol(protocol) {
+			continue
+		}
+		if _, ok := m[servent.Port]; !ok {
+			m[servent.Port] = *servent.clone()
+		}
+	}
+	return m
+}
+
+// ToServiceNameMap returns a map from canonical service name to a
+// copy of the corresponding entry in Services. If the protocol is
+// nil, services of all protocols are included and the first service
+// with a name wins.
+func ToServiceNameMap(protocol *Protoent) map[string]Servent {
+	m := make(map[string]Servent)
+	for _, servent := range Services {
+		if !servent.hasProtocol(protocol) {
+			continue
+		}
+		if _, ok := m[servent.Name]; !ok {
+			m[servent.Name] = *servent.clone()
+		}
+	}
+	return m
+}
+
+// ByServicePort returns a map from port number and protocol name to
+// a copy of the corresponding entry in Services, so that
+// m[80]["tcp"] is the HTTP service. The first service on a port and
+// protocol wins. The map is a snapshot and does not reflect later
+// changes to Services.
+func ByServicePort() map[int]map[string]Servent {
+	m := make(map[int]map[string]Servent)
+	for _, servent := range Services {
+		key := servent.key()
+		byProto, ok := m[key.Port]
+		if !ok {
+			byProto = make(map[string]Servent)
+			m[key.Port] = byProto
+		}
+		if _, ok := byProto[key.Proto]; !ok {
+			byProto[key.Proto] = *servent.clone()
+		}
+	}
+	return m
+}
+
+// ByServiceName returns a map from canonical service name to copies
+// of all entries in Services with that name, across all protocols, in
+// the order of Services. The map is a snapshot and does not reflect
+// later changes to Services.
+func ByServiceName() map[string][]Servent {
+	m := make(map[string][]Servent)
+	for _, servent := range Services {
+		m[servent.Name] = append(m[servent.Name], *servent.clone())
+	}
+	return m
+}
+
+// ByProtocolName returns a map from every protocol name and alias to
+// a copy of the corresponding entry in Protocols. The first protocol
+// using a name wins. The map is a snapshot and does not reflect later
+// changes to Protocols.
+func ByProtocolName() map[string]Protoent {
+	m := make(map[string]Protoent)
+	for _, protoent := range Protocols {
+		for _, name := range protoent.names() {
+			if _, ok := m[name]; !ok {
+				m[name] = *protoent.clone()
+			}
+		}
+	}
+	return m
+}
+
+// ToProtoentSlice returns copies of all entries in Protocols. If
+// expandAliases is true, every entry is replaced by one entry for
+// its name and one for each of its aliases, each with that name and
+// no aliases.
+func ToProtoentSlice(expandAliases bool) []Protoent {
+	var out []Protoent
+	for _, protoent := range Protocols {
+		if !expandAliases {
+			out = append(out, *protoent.clone())
+			continue
+		}
+		for _, name := range append([]string{protoent.Name}, protoent.Aliases...) {
+			out = append(out, Protoent{Name: name, Number: protoent.Number})
+		}
+	}
+	return out
+}
+
+// ToServentSlice returns copies of all entries in Services. If
+// expandAliases is true, every entry is replaced by one entry for
+// its name and one for each of its aliases, each with that name and
+// no aliases.
+func ToServentSlice(expandAliases bool) []Servent {
+	var out []Servent
+	for _, servent := range Services {
+		if !expandAliases {
+			out = append(out, *servent.clone())
+			continue
+		}
+		for _, name := range append([]string{servent.Name}, servent.Aliases...) {
+			out = append(out, Servent{Name: name, Port: servent.Port, Protocol: servent.Protocol.clone()})
+		}
+	}
+	return out
+}
+
+// ProtocolAliasMap returns a map from the name and every alias of the
+// entries in Protocols to the canonical protocol name. If a name is
+// used by more than one protocol, the first one wins, as in
+// GetProtoByName.
+func ProtocolAliasMap() map[string]string {
+	m := make(map[string]string)
+	for _, protoent := range Protocols {
+		for _, name := range protoent.names() {
+			if _, ok := m[name]; !ok {
+				m[name] = protoent.Name
+			}
+		}
+	}
+	return m
+}
+
+// ServiceAliasMap returns a map from the name and every alias of the
+// services of a protocol to the canonical service name. If the
+// protocol is nil, services of all protocols are included, with TCP
+// services taking precedence when a name is used more than once.
+// Otherwise, the first service using a name wins.
+func ServiceAliasMap(protocol *Protoent) map[string]string {
+	m := make(map[string]string)
+	add := func(servent *Servent) {
+		for _, name := range servent.names() {
+			if _, ok := m[name]; !ok {
+				m[name] = servent.Name
+			}
+		}
+	}
+
+	if protocol != nil {
+		for _, servent := range Services {
+			if servent.hasProtocol(protocol) {
+				add(servent)
+			}
+		}
+		return m
+	}
+
+	isTCP := func(servent *Servent) bool {
+		return servent.Protocol != nil && servent.Protocol.Name == "tcp"
+	}
+	for _, servent := range Services {
+		if isTCP(servent) {
+			add(servent)
+		}
+	}
+	for _, servent := range Services {
+		if !isTCP(servent) {
+			add(servent)
+		}
+	}
+	return m
+}
+
+func (this *Protoent) clone() *Protoent {
+	if this == nil {
+		return nil
+	}
+
+	protoent := *this
+	protoent.Aliases = append([]string(nil), this.Aliases...)
+	return &protoent
+}
+
+func (this *Servent) clone() *Servent {
+	if this == nil {
+		return nil
+	}
+
+	servent := *this
+	servent.Aliases = append([]string(nil), this.Aliases...)
+	servent.Protocol = this.Protocol.clone()
+	return &servent
+}
@@ -0,0 +1,97 @@
+package netdb
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TransformProtocols calls fn with every entry in Protocols, allowing
+// it to modify the entries in place. Services refer to the same
+// entries and observe the changes.
+func TransformProtocols(fn func(protoent *Protoent)) {
+	for _, protoent := range Protocols {
+		fn(protoent)
+	}
+}
+
+// TransformServices calls fn with every entry in Services, allowing
+// it to modify the entries in place.
+func TransformServices(fn func(servent *Servent)) {
+	for _, servent := range Services {
+		fn(servent)
+	}
+}
+
+// GetOrRegisterProtocol returns the entry in Protocols with the same
+// number as p and false if there is one. Otherwise, it appends p to
+// Protocols and returns p and true. If p is not valid, as reported by
+// Validate, nothing is registered and the error is returned.
+func GetOrRegisterProtocol(p *Protoent) (*Protoent, bool, error) {
+	if err := p.Validate(); err != nil {
+		return nil, false, err
+	}
+	if protoent := GetProtoByNumber(p.Number); protoent != nil {
+		return protoent, false, nil
+	}
+
+	Protocols = append(Protocols, p)
+	return p, true, nil
+}
+
+// GetOrRegisterService returns the entry in Services with the same
+// port and protocol as s and false if there is one. Otherwise, it
+// appends s to Services and returns s and true. If s is not valid, as
+// reported by Validate, nothing is registered and the error is
+// returned.
+func GetOrRegisterService(s *Servent) (*Servent, bool, error) {
+	if err := s.Validate(); err != nil {
+		return nil, false, err
+	}
+	if servent := GetServByPort(s.Port, s.Protocol); servent != nil {
+		return servent, false, nil
+	}
+
+	Services = append(Services, s)
+	return s, true, nil
+}
+
+// UpdateProtocol calls fn with a copy of the entry in Protocols with
+// the given number and, if fn did not change the number, stores the
+// modified copy in place of the entry. If no protocol has the number,
+// an error wrapping ErrNotFound is returned.
+func UpdateProtocol(number int, fn func(*Protoent)) error {
+	protoent := GetProtoByNumber(number)
+	if protoent == nil {
+		return fmt.Errorf("%w: protocol %d", ErrNotFound, number)
+	}
+
+	updated := protoent.clone()
+	fn(updated)
+	if updated.Number != number {
+		return errors.New("netdb: UpdateProtocol must not change the protocol number")
+	}
+
+	*protoent = *updated
+	return nil
+}
+
+// UpdateService calls fn with a copy of the entry returned by
+// GetServByPort and, if fn did not change the port or protocol,
+// stores the modified copy in place of the entry. If no service
+// matches, an error wrapping ErrNotFound is returned.
+func UpdateService(port int, protocol *Protoent, fn func(*Servent)) error {
+	servent := GetServByPort(port, protocol)
+	if servent == nil {
+		return fmt.Errorf("%w: service on port %d", ErrNotFound, port)
+	}
+
+	updated := *servent
+	updated.Aliases = append([]string(nil), servent.Aliases...)
+	fn(&updated)
+	if updated.Port != servent.Port || !updated.Protocol.Equal(servent.Protocol) {
+		return errors.New("netdb: UpdateService must not change the port or protocol")
+	}
+
+	*servent = updated
+	return nil
+}
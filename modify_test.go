@@ -0,0 +1,72 @@
+package netdb_test
+
+import (
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+func TestUpdateServiceNilProtocol(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	err := netdb.UpdateService(443, nil, func(servent *netdb.Servent) {
+		servent.Aliases = append(servent.Aliases, "http-tls")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if servent := netdb.LookupService("http-tls", nil); servent == nil || servent.Port != 443 {
+		t.Errorf("LookupService(\"http-tls\", nil) = %v, want https", servent)
+	}
+
+	err = netdb.UpdateService(443, nil, func(servent *netdb.Servent) {
+		servent.Protocol = netdb.GetProtoByName("udp")
+	})
+	if err == nil {
+		t.Errorf("UpdateService allowed changing the protocol")
+	}
+}
+
+func TestGetOrRegisterProtocol(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	if got, ok, err := netdb.GetOrRegisterProtocol(&netdb.Protoent{Name: "TCP", Number: 6}); err != nil || ok || got != netdb.GetProtoByName("tcp") {
+		t.Errorf("GetOrRegisterProtocol(TCP) = %v, %t, %v, want the existing tcp", got, ok, err)
+	}
+
+	sctp := &netdb.Protoent{Name: "sctp", Number: 132}
+	if got, ok, err := netdb.GetOrRegisterProtocol(sctp); err != nil || !ok || got != sctp {
+		t.Errorf("GetOrRegisterProtocol(sctp) = %v, %t, %v, want sctp, true", got, ok, err)
+	}
+
+	n := len(netdb.Protocols)
+	if _, ok, err := netdb.GetOrRegisterProtocol(&netdb.Protoent{Number: 300}); err == nil || ok {
+		t.Errorf("GetOrRegisterProtocol registered an invalid protocol")
+	}
+	if len(netdb.Protocols) != n {
+		t.Errorf("invalid protocol was appended to Protocols")
+	}
+}
+
+func TestGetOrRegisterService(t *testing.T) {
+	netdbtest.UseFixture(t)
+	tcp := netdb.GetProtoByName("tcp")
+
+	if got, ok, err := netdb.GetOrRegisterService(&netdb.Servent{Name: "www", Port: 80, Protocol: tcp}); err != nil || ok || got.Name != "http" {
+		t.Errorf("GetOrRegisterService(www) = %v, %t, %v, want the existing http", got, ok, err)
+	}
+
+	smtp := &netdb.Servent{Name: "smtp", Port: 25, Protocol: tcp}
+	if got, ok, err := netdb.GetOrRegisterService(smtp); err != nil || !ok || got != smtp {
+		t.Errorf("GetOrRegisterService(smtp) = %v, %t, %v, want smtp, true", got, ok, err)
+	}
+
+	n := len(netdb.Services)
+	if _, ok, err := netdb.GetOrRegisterService(&netdb.Servent{Name: "gopher", Port: 70}); err == nil || ok {
+		t.Errorf("GetOrRegisterService registered a service without protocol")
+	}
+	if len(netdb.Services) != n {
+		t.Errorf("invalid service was appended to Services")
+	}
+}
@@ -0,0 +1,56 @@
+package netdb
+
+import "sort"
+
+// AllServiceNames returns the name of every entry in Services,
+// sorted and without duplicates. Aliases are not included.
+func AllServiceNames() []string {
+	names := make([]string, 0, len(Services))
+	for _, servent := range Services {
+		names = append(names, servent.Name)
+	}
+	return sortUnique(names)
+}
+
+// AllProtocolNames returns the name of every entry in Protocols,
+// sorted and without duplicates. Aliases are not included.
+func AllProtocolNames() []string {
+	names := make([]string, 0, len(Protocols))
+	for _, protoent := range Protocols {
+		names = append(names, protoent.Name)
+	}
+	return sortUnique(names)
+}
+
+// AllServiceAliases returns every alias of any entry in Services,
+// sorted and without duplicates.
+func AllServiceAliases() []string {
+	var aliases []string
+	for _, servent := range Services {
+		aliases = append(aliases, servent.Aliases...)
+	}
+	return sortUnique(aliases)
+}
+
+// AllProtocolAliases returns every alias of any entry in Protocols,
+// sorted and without duplicates.
+func AllProtocolAliases() []string {
+	var aliases []string
+	for _, protoent := range Protocols {
+		aliases = append(aliases, protoent.Aliases...)
+	}
+	return sortUnique(aliases)
+}
+
+// sortUnique sorts strs in place and removes duplicates.
+func sortUnique(strs []string) []string {
+	sort.Strings(strs)
+
+	out := strs[:0]
+	for i, s := range strs {
+		if i == 0 || s != strs[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+package netdb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// GetServentForListener returns the Servent for the port and protocol
+// a listener is bound to. It returns nil if the listener's address
+// has no port or its network is not a known protocol.
+func GetServentForListener(l net.Listener) *Servent {
+	return getServentForAddr(l.Addr())
+}
+
+func getServentForAddr(addr net.Addr) *Servent {
+	protocol := protocolForNetwork(addr.Network())
+	if protocol == nil {
+		return nil
+	}
+
+	_, portStr, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil
+	}
+
+	return GetServByPort(port, protocol)
+}
+
+// GetProtoentForConn returns the Protoent for the network of a
+// connection, such as TCP for a *net.TCPConn. It returns nil if the
+// network is not a known protocol.
+func GetProtoentForConn(c net.Conn) *Protoent {
+	return protocolForNetwork(c.LocalAddr().Network())
+}
+
+// protocolForNetwork returns the Protoent for a network name as used
+// by package net, such as "tcp" or "udp6".
+func protocolForNetwork(network string) *Protoent {
+	network = strings.TrimRight(network, "46")
+	if network == "" {
+		return nil
+	}
+	return GetProtoByName(network)
+}
+
+// PortToNetworkOrder returns port as a 16-bit integer in network byte
+// order. Only the lower 16 bits of port are used.
+func PortToNetworkOrder(port int) [2]byte {
+	return [2]byte{byte(port >> 8), byte(port)}
+}
+
+// PortFromNetworkOrder returns the port stored as a 16-bit integer in
+// network byte order.
+func PortFromNetworkOrder(b [2]byte) int {
+	return int(b[0])<<8 | int(b[1])
+}
+
+// ServiceToTCPAddr returns the TCP address of a service on host, which
+// must be an IP address, optionally with an IPv6 zone, or empty. It is
+// an error for the service not to be a TCP service.
+func ServiceToTCPAddr(s *Servent, host string) (*net.TCPAddr, error) {
+	if err := checkTransport(s, "tcp"); err != nil {
+		return nil, err
+	}
+	ip, zone, err := parseHost(host)
+	if err != nil {
+		return nil, err
+	}
+	return &net.TCPAddr{IP: ip, Port: s.Port, Zone: zone}, nil
+}
+
+// ServiceToUDPAddr returns the UDP address of a service on host, which
+// must be an IP address, optionally with an IPv6 zone, or empty. It is
+// an error for the service not to be a UDP service.
+func ServiceToUDPAddr(s *Servent, host string) (*net.UDPAddr, error) {
+	if err := checkTransport(s, "udp"); err != nil {
+		return nil, err
+	}
+	ip, zone, err := parseHost(host)
+	if err != nil {
+		return nil, err
+	}
+	return &net.UDPAddr{IP: ip, Port: s.Port, Zone: zone}, nil
+}
+
+func checkTransport(s *Servent, proto string) error {
+	if s.Protocol == nil || s.Protocol.Name != proto {
+		return fmt.Errorf("netdb: service %s is not a %s service", s.Name, proto)
+	}
+	return nil
+}
+
+func parseHost(host string) (ip net.IP, zone string, err error) {
+	if host == "" {
+		return nil, "", nil
+	}
+	if i := strings.LastIndexByte(host, '%'); i >= 0 {
+		host, zone = host[:i], host[i+1:]
+	}
+	ip = net.ParseIP(host)
+	if ip == nil {
+		return nil, "", &net.AddrError{Err: "invalid IP address", Addr: host}
+	}
+	return ip, zone, nil
+}
+
+// Listen looks up the TCP port of a service and listens on it on all
+// local addresses.
+func Listen(serviceName string) (net.Listener, error) {
+	servent, err := findTransportService(serviceName, "tcp")
+	if err != nil {
+		return nil, err
+	}
+	return net.Listen("tcp", ":"+strconv.Itoa(servent.Port))
+}
+
+// ListenUDP looks up the UDP port of a service and listens on it on
+// all local addresses.
+func ListenUDP(serviceName string) (*net.UDPConn, error) {
+	servent, err := findTransportService(serviceName, "udp")
+	if err != nil {
+		return nil, err
+	}
+	return net.ListenUDP("udp", &net.UDPAddr{Port: servent.Port})
+}
+
+// Dial looks up the TCP port of a service and connects to it on host.
+func Dial(host, serviceName string) (net.Conn, error) {
+	return DialContext(context.Background(), host, serviceName)
+}
+
+// DialContext is like Dial but uses the provided context.
+func DialContext(ctx context.Context, host, serviceName string) (net.Conn, error) {
+	servent, err := findTransportService(serviceName, "tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(servent.Port)))
+}
+
+// findTransportService returns the service with the given name or
+// alias for proto, or an error wrapping ErrNotFound.
+func findTransportService(name, proto string) (*Servent, error) {
+	if protocol := GetProtoByName(proto); protocol != nil {
+		if servent := GetServByName(name, protocol); servent != nil {
+			return servent, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: service %s/%s", ErrNotFound, name, proto)
+}
+
+// TCPAddr returns the TCP address of the service on host, which must
+// be an IP address, optionally with an IPv6 zone, or empty. It panics
+// if the service is not a TCP service or host is invalid.
+func (this *Servent) TCPAddr(host string) *net.TCPAddr {
+	addr, err := ServiceToTCPAddr(this, host)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// UDPAddr returns the UDP address of the service on host, which must
+// be an IP address, optionally with an IPv6 zone, or empty. It panics
+// if the service is not a UDP service or host is invalid.
+func (this *Servent) UDPAddr(host string) *net.UDPAddr {
+	addr, err := ServiceToUDPAddr(this, host)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// Addr returns the address of the service on host, as a *net.TCPAddr
+// or *net.UDPAddr depending on the service's protocol. It returns nil
+// for other protocols and panics if host is invalid.
+func (this *Servent) Addr(host string) net.Addr {
+	if this.Protocol == nil {
+		return nil
+	}
+
+	switch this.Protocol.Name {
+	case "tcp":
+		return this.TCPAddr(host)
+	case "udp":
+		return this.UDPAddr(host)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package netdb_test
+
+import (
+	"testing"
+
+	"honnef.co/go/netdb"
+)
+
+func TestPortNetworkOrder(t *testing.T) {
+	tests := []struct {
+		port int
+		want [2]byte
+	}{
+		{0, [2]byte{0x00, 0x00}},
+		{80, [2]byte{0x00, 0x50}},
+		{443, [2]byte{0x01, 0xBB}},
+		{65535, [2]byte{0xFF, 0xFF}},
+	}
+
+	for _, tt := range tests {
+		got := netdb.PortToNetworkOrder(tt.port)
+		if got != tt.want {
+			t.Errorf("PortToNetworkOrder(%d) = %#v, want %#v", tt.port, got, tt.want)
+		}
+		if port := netdb.PortFromNetworkOrder(got); port != tt.port {
+			t.Errorf("PortFromNetworkOrder(%#v) = %d, want %d", got, port, tt.port)
+		}
+	}
+}
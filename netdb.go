@@ -4,15 +4,24 @@
 // A pure Go implementation is used by parsing /etc/protocols and
 // /etc/services
 //
+// If either file cannot be loaded, a warning is logged and both
+// Protocols and Services remain empty. EnsureLoaded reports the
+// error.
+//
 // All return values are pointers that point to the entries in the
 // original list of protocols and services. Manipulating the entries
 // would affect the entire program.
 package netdb // import "honnef.co/go/netdb"
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"log"
 	"strconv"
-	"strings"
+	"sync"
+	"time"
 )
 
 type Protoent struct {
@@ -28,76 +37,290 @@ type Servent struct {
 	Protocol *Protoent
 }
 
+// Limits of protocol numbers and service ports.
+const (
+	MaxProtocolNumber = 255
+	MinServicePort    = 0
+	MaxServicePort    = 65535
+)
+
 // These variables get populated from /etc/protocols and /etc/services
-// respectively.
+// respectively, or their equivalents on the current operating system.
 var (
 	Protocols []*Protoent
 	Services  []*Servent
 )
 
+// ErrNotFound is returned, possibly wrapped, when a requested
+// protocol or service does not exist.
+var ErrNotFound = errors.New("netdb: not found")
+
+var (
+	// loadErr is the error of the last load attempt, if any.
+	loadErr error
+	// loadTime is the time of the last successful load attempt.
+	loadTime time.Time
+	// protocolsFile and servicesFile are the files Protocols and
+	// Services were loaded from, or empty if they were loaded from
+	// strings or never loaded.
+	protocolsFile, servicesFile string
+
+	// readFile is used by load to read files. Benchmarks replace it
+	// to simulate slow I/O.
+	readFile = ioutil.ReadFile
+)
+
 func init() {
-	protoMap := make(map[string]*Protoent)
+	load(GetDefaultProtocolsPath(), GetDefaultServicesPath())
+}
 
-	// Load protocols
-	data, err := ioutil.ReadFile("/etc/protocols")
+// load loads Protocols and Services from the given files. On failure,
+// it logs a warning, records the error and leaves both lists as they
+// are.
+func load(protoFile, servFile string) {
+	// The two files are independent of each other, so read them
+	// concurrently. Parsing the services requires the protocols and
+	// happens afterwards.
+	var protoData, servData []byte
+	var protoErr, servErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		protoData, protoErr = readFile(protoFile)
+	}()
+	go func() {
+		defer wg.Done()
+		servData, servErr = readFile(servFile)
+	}()
+	wg.Wait()
+
+	if protoErr != nil {
+		warnLoad(protoFile, protoErr)
+		return
+	}
+	protocols, err := parseProtocolsBytes(protoData)
 	if err != nil {
-		panic(err)
+		warnLoad(protoFile, err)
+		return
 	}
 
-	for _, line := range strings.Split(string(data), "\n") {
-		line = strings.TrimSpace(line)
-		split := strings.SplitN(line, "#", 2)
-		fields := strings.Fields(split[0])
-		if len(fields) < 2 {
-			continue
-		}
+	if servErr != nil {
+		warnLoad(servFile, servErr)
+		return
+	}
+	services, err := loadServices(servData, protocols)
+	if err != nil {
+		warnLoad(servFile, err)
+		return
+	}
+
+	Protocols, Services = protocols, services
+	protocolsFile, servicesFile = protoFile, servFile
+	loadErr, loadTime = nil, time.Now()
+}
+
+// warnLoad logs and records an error encountered while loading file.
+// Protocols and Services are left as they are.
+func warnLoad(file string, err error) {
+	log.Printf("netdb: warning: could not load %s: %v", file, err)
+	loadErr = fmt.Errorf("netdb: could not load %s: %w", file, err)
+}
 
-		num, err := strconv.ParseInt(fields[1], 10, 32)
-		if err != nil {
-			panic(err)
+// loadServices parses services from data, resolving their protocols
+// by name or alias among protocols. If several protocols use a name,
+// the first one wins, the same way GetProtoByName resolves names
+// against Protocols.
+func loadServices(data []byte, protocols []*Protoent) ([]*Servent, error) {
+	protoMap := make(map[string]*Protoent)
+	for _, protoent := range protocols {
+		for _, name := range protoent.names() {
+			if _, ok := protoMap[name]; !ok {
+				protoMap[name] = protoent
+			}
 		}
+	}
 
-		protoent := &Protoent{
-			Name:    fields[0],
-			Aliases: fields[2:],
-			Number:  int(num),
+	return parseServicesBytes(data, func(name []byte) *Protoent {
+		return protoMap[string(name)]
+	})
+}
+
+// ParseProtocolsBytes parses data in the format of /etc/protocols.
+func ParseProtocolsBytes(data []byte) ([]*Protoent, error) {
+	protocols, err := parseProtocolsBytes(data)
+	if err != nil {
+		err = fmt.Errorf("netdb: %w", err)
+	}
+	return protocols, err
+}
+
+func parseProtocolsBytes(data []byte) ([]*Protoent, error) {
+	var protocols []*Protoent
+	err := eachLine(data, func(line []byte) error {
+		protoent, err := parseProtocolLine(line)
+		if protoent != nil {
+			protocols = append(protocols, protoent)
 		}
-		Protocols = append(Protocols, protoent)
+		return err
+	})
+	return protocols, err
+}
 
-		protoMap[fields[0]] = protoent
+// ParseServicesBytes parses data in the format of /etc/services.
+// Protocols are resolved by name or alias against the current
+// Protocols, using GetProtoByName. Errors are wrapped with the line
+// number and can be inspected with errors.As.
+func ParseServicesBytes(data []byte) ([]*Servent, error) {
+	services, err := parseServicesBytes(data, protoByName)
+	if err != nil {
+		err = fmt.Errorf("netdb: %w", err)
 	}
+	return services, err
+}
 
-	// Load services
-	data, err = ioutil.ReadFile("/etc/services")
+// LoadProtocolsFromString replaces Protocols with the entries parsed
+// from s, which is in the format of /etc/protocols. On error,
+// Protocols is left unchanged. In either case, the result is
+// recorded as the last load attempt reported by EnsureLoaded,
+// LastLoadTime and LastLoadError.
+func LoadProtocolsFromString(s string) error {
+	protocols, err := ParseProtocolsBytes([]byte(s))
 	if err != nil {
-		panic(err)
+		loadErr = err
+		return err
 	}
+	Protocols, protocolsFile = protocols, ""
+	loadErr, loadTime = nil, time.Now()
+	return nil
+}
 
-	for _, line := range strings.Split(string(data), "\n") {
-		line = strings.TrimSpace(line)
-		split := strings.SplitN(line, "#", 2)
-		fields := strings.Fields(split[0])
-		if len(fields) < 2 {
-			continue
+// LoadServicesFromString replaces Services with the entries parsed
+// from s, which is in the format of /etc/services. Protocols are
+// resolved against the current Protocols. On error, Services is left
+// unchanged. In either case, the result is recorded as the last load
+// attempt reported by EnsureLoaded, LastLoadTime and LastLoadError.
+func LoadServicesFromString(s string) error {
+	services, err := ParseServicesBytes([]byte(s))
+	if err != nil {
+		loadErr = err
+		return err
+	}
+	Services, servicesFile = services, ""
+	loadErr, loadTime = nil, time.Now()
+	return nil
+}
+
+func parseServicesBytes(data []byte, lookup func(name []byte) *Protoent) ([]*Servent, error) {
+	var services []*Servent
+	err := eachLine(data, func(line []byte) error {
+		servent, err := parseServiceLine(line, lookup)
+		if servent != nil {
+			services = append(services, servent)
 		}
+		return err
+	})
+	return services, err
+}
 
-		name := fields[0]
-		portproto := strings.SplitN(fields[1], "/", 2)
-		port, err := strconv.ParseInt(portproto[0], 10, 32)
-		if err != nil {
-			panic(err)
+// eachLine calls fn for every line in data, stopping at the first
+// error, which is wrapped with the line number. The lines share their
+// memory with data.
+func eachLine(data []byte, fn func(line []byte) error) error {
+	for n := 1; len(data) > 0; n++ {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
 		}
 
-		proto := portproto[1]
-		aliases := fields[2:]
+		if err := fn(line); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return nil
+}
+
+// fields strips the comment from line and splits the rest into
+// whitespace-separated fields.
+func fields(line []byte) [][]byte {
+	if i := bytes.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	return bytes.Fields(line)
+}
+
+// fieldStrings converts fields to strings. The result is never nil.
+func fieldStrings(fields [][]byte) []string {
+	s := make([]string, len(fields))
+	for i, field := range fields {
+		s[i] = string(field)
+	}
+	return s
+}
+
+// protoByName is like GetProtoByName, for names that haven't been
+// converted to strings yet.
+func protoByName(name []byte) *Protoent {
+	return GetProtoByName(string(name))
+}
+
+// ParseProtocolLine parses a single line in the format of
+// /etc/protocols. If the line doesn't contain an entry, for example
+// because it is empty or only holds a comment, nil is returned.
+func ParseProtocolLine(line string) (*Protoent, error) {
+	return parseProtocolLine([]byte(line))
+}
+
+func parseProtocolLine(line []byte) (*Protoent, error) {
+	fields := fields(line)
+	if len(fields) < 2 {
+		return nil, nil
+	}
+
+	num, err := strconv.ParseInt(string(fields[1]), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Protoent{
+		Name:    string(fields[0]),
+		Aliases: fieldStrings(fields[2:]),
+		Number:  int(num),
+	}, nil
+}
+
+// ParseServiceLine parses a single line in the format of
+// /etc/services. The protocol is resolved using GetProtoByName and
+// is nil if it is unknown. If the line doesn't contain an entry, for
+// example because it is empty or only holds a comment, nil is
+// returned.
+func ParseServiceLine(line string) (*Servent, error) {
+	return parseServiceLine([]byte(line), protoByName)
+}
+
+func parseServiceLine(line []byte, lookup func(name []byte) *Protoent) (*Servent, error) {
+	fields := fields(line)
+	if len(fields) < 2 {
+		return nil, nil
+	}
 
-		Services = append(Services, &Servent{
-			Name:     name,
-			Aliases:  aliases,
-			Port:     int(port),
-			Protocol: protoMap[proto],
-		})
+	i := bytes.IndexByte(fields[1], '/')
+	if i < 0 {
+		return nil, fmt.Errorf("invalid port/protocol %q", fields[1])
+	}
+	port, err := strconv.ParseInt(string(fields[1][:i]), 10, 32)
+	if err != nil {
+		return nil, err
 	}
+
+	return &Servent{
+		Name:     string(fields[0]),
+		Aliases:  fieldStrings(fields[2:]),
+		Port:     int(port),
+		Protocol: lookup(fields[1][i+1:]),
+	}, nil
 }
 
 // Equal checks if two Protoents are the same, which is the case if
@@ -131,6 +354,111 @@ func (this *Servent) Equal(other *Servent) bool {
 		this.Protocol.Equal(other.Protocol)
 }
 
+// Validate checks that the protocol has a name, that its number is
+// within [0, MaxProtocolNumber] and that none of its aliases equals
+// its name.
+func (this *Protoent) Validate() error {
+	if this.Name == "" {
+		return errors.New("netdb: protocol has no name")
+	}
+	if this.Number < 0 || this.Number > MaxProtocolNumber {
+		return fmt.Errorf("netdb: protocol %s: number %d out of range", this.Name, this.Number)
+	}
+	for _, alias := range this.Aliases {
+		if alias == this.Name {
+			return fmt.Errorf("netdb: protocol %s: alias equals name", this.Name)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the service has a name and a protocol and that
+// its port is within [MinServicePort, MaxServicePort].
+func (this *Servent) Validate() error {
+	if this.Name == "" {
+		return errors.New("netdb: service has no name")
+	}
+	if this.Port < MinServicePort || this.Port > MaxServicePort {
+		return fmt.Errorf("netdb: service %s: port %d out of range", this.Name, this.Port)
+	}
+	if this.Protocol == nil || this.Protocol.Name == "" {
+		return fmt.Errorf("netdb: service %s: no protocol", this.Name)
+	}
+	return nil
+}
+
+// IPProto returns the protocol number as used in the protocol field
+// of IPv4 headers and the next header field of IPv6 headers.
+func (this *Protoent) IPProto() uint8 {
+	return uint8(this.Number)
+}
+
+// IPProtoString returns the protocol number in hexadecimal, such as
+// "0x06" for TCP.
+func (this *Protoent) IPProtoString() string {
+	return fmt.Sprintf("0x%02x", this.IPProto())
+}
+
+func (this *Protoent) hasName(name string) bool {
+	if this.Name == name {
+		return true
+	}
+
+	for _, alias := range this.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// names returns the protocol's name followed by its aliases, without
+// duplicates.
+func (this *Protoent) names() []string {
+	return uniqueNames(this.Name, this.Aliases)
+}
+
+func (this *Servent) hasName(name string) bool {
+	if this.Name == name {
+		return true
+	}
+
+	for _, alias := range this.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// names returns the service's name followed by its aliases, without
+// duplicates.
+func (this *Servent) names() []string {
+	return uniqueNames(this.Name, this.Aliases)
+}
+
+func uniqueNames(name string, aliases []string) []string {
+	names := []string{name}
+outer:
+	for _, alias := range aliases {
+		for _, other := range names {
+			if alias == other {
+				continue outer
+			}
+		}
+		names = append(names, alias)
+	}
+	return names
+}
+
+// hasProtocol reports whether the service belongs to protocol. A nil
+// protocol matches services of any protocol.
+func (this *Servent) hasProtocol(protocol *Protoent) bool {
+	return protocol == nil || this.Protocol.Equal(protocol)
+}
+
 // GetProtoByNumber returns the Protoent for a given protocol number.
 func GetProtoByNumber(num int) (protoent *Protoent) {
 	for _, protoent := range Protocols {
@@ -164,7 +492,7 @@ func GetProtoByName(name string) (protoent *Protoent) {
 // the service name is returned.
 func GetServByName(name string, protocol *Protoent) (servent *Servent) {
 	for _, servent := range Services {
-		if !servent.Protocol.Equal(protocol) {
+		if !servent.hasProtocol(protocol) {
 			continue
 		}
 
@@ -187,7 +515,21 @@ func GetServByName(name string, protocol *Protoent) (servent *Servent) {
 // port number is returned.
 func GetServByPort(port int, protocol *Protoent) *Servent {
 	for _, servent := range Services {
-		if servent.Port == port && servent.Protocol.Equal(protocol) {
+		if servent.Port == port && servent.hasProtocol(protocol) {
+			return servent
+		}
+	}
+
+	return nil
+}
+
+// GetServByNameAndPort returns the Servent for a given service name
+// or alias, port number and protocol. A service only matches if both
+// its name and its port match. If the protocol is nil, the first
+// service matching name and port is returned.
+func GetServByNameAndPort(name string, port int, protocol *Protoent) *Servent {
+	for _, servent := range Services {
+		if servent.Port == port && servent.hasProtocol(protocol) && servent.hasName(name) {
 			return servent
 		}
 	}
@@ -0,0 +1,40 @@
+package netdbtest
+
+import (
+	"testing"
+
+	"honnef.co/go/netdb"
+)
+
+// Fixture returns a small, fixed set of protocols and services: the
+// protocols ICMP, TCP and UDP, and the services ssh (22/tcp), domain
+// (53/tcp and 53/udp), http (80/tcp) and https (443/tcp). Every call
+// returns newly allocated entries.
+func Fixture() (protocols []*netdb.Protoent, services []*netdb.Servent) {
+	icmp := &netdb.Protoent{Name: "icmp", Aliases: []string{"ICMP"}, Number: 1}
+	tcp := &netdb.Protoent{Name: "tcp", Aliases: []string{"TCP"}, Number: 6}
+	udp := &netdb.Protoent{Name: "udp", Aliases: []string{"UDP"}, Number: 17}
+
+	protocols = []*netdb.Protoent{icmp, tcp, udp}
+	services = []*netdb.Servent{
+		{Name: "ssh", Aliases: []string{}, Port: 22, Protocol: tcp},
+		{Name: "domain", Aliases: []string{}, Port: 53, Protocol: tcp},
+		{Name: "domain", Aliases: []string{}, Port: 53, Protocol: udp},
+		{Name: "http", Aliases: []string{"www"}, Port: 80, Protocol: tcp},
+		{Name: "https", Aliases: []string{}, Port: 443, Protocol: tcp},
+	}
+	return protocols, services
+}
+
+// UseFixture replaces netdb.Protocols and netdb.Services with the
+// entries returned by Fixture for the duration of the test. Tests
+// using it must not run in parallel.
+func UseFixture(t testing.TB) {
+	t.Helper()
+
+	oldProtocols, oldServices := netdb.Protocols, netdb.Services
+	netdb.Protocols, netdb.Services = Fixture()
+	t.Cleanup(func() {
+		netdb.Protocols, netdb.Services = oldProtocols, oldServices
+	})
+}
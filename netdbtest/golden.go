@@ -0,0 +1,56 @@
+// Package netdbtest provides utilities for testing code that uses
+// package netdb.
+package netdbtest // import "honnef.co/go/netdb/netdbtest"
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"honnef.co/go/netdb"
+)
+
+// GoldenTest compares the output of netdb.DebugDump against the
+// contents of goldenFile and fails the test if they differ. If
+// goldenFile does not exist or the environment variable GOLDEN_UPDATE
+// is set to 1, the file is written instead.
+func GoldenTest(t testing.TB, goldenFile string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := netdb.DebugDump(&buf); err != nil {
+		t.Fatalf("dumping netdb: %v", err)
+	}
+	got := buf.Bytes()
+
+	want, err := ioutil.ReadFile(goldenFile)
+	if os.IsNotExist(err) || os.Getenv("GOLDEN_UPDATE") == "1" {
+		if err := ioutil.WriteFile(goldenFile, got, 0644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		gotLines := strings.Split(string(got), "\n")
+		wantLines := strings.Split(string(want), "\n")
+		for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+			var g, w string
+			if i < len(gotLines) {
+				g = gotLines[i]
+			}
+			if i < len(wantLines) {
+				w = wantLines[i]
+			}
+			if g != w {
+				t.Errorf("netdb differs from %s at line %d:\ngot:  %q\nwant: %q", goldenFile, i+1, g, w)
+				return
+			}
+		}
+	}
+}
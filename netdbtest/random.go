@@ -0,0 +1,42 @@
+package netdbtest
+
+import (
+	"math/rand"
+
+	"honnef.co/go/netdb"
+)
+
+// RandomProtocol returns an entry of netdb.Protocols chosen uniformly
+// at random, or nil if there are none. If rng is nil, the top-level
+// functions of math/rand are used.
+func RandomProtocol(rng *rand.Rand) *netdb.Protoent {
+	if len(netdb.Protocols) == 0 {
+		return nil
+	}
+	return netdb.Protocols[intn(rng, len(netdb.Protocols))]
+}
+
+// RandomService returns an entry of netdb.Services of the given
+// protocol chosen uniformly at random, or nil if there are none. If
+// the protocol is nil, services of all protocols are considered. If
+// rng is nil, the top-level functions of math/rand are used.
+func RandomService(rng *rand.Rand, protocol *netdb.Protoent) *netdb.Servent {
+	var candidates []*netdb.Servent
+	for _, servent := range netdb.Services {
+		if protocol == nil || servent.Protocol.Equal(protocol) {
+			candidates = append(candidates, servent)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[intn(rng, len(candidates))]
+}
+
+func intn(rng *rand.Rand, n int) int {
+	if rng == nil {
+		return rand.Intn(n)
+	}
+	return rng.Intn(n)
+}
@@ -0,0 +1,37 @@
+package netdbtest
+
+import (
+	"fmt"
+
+	"honnef.co/go/netdb"
+)
+
+// Synthetic returns a generated set of n protocols and n services per
+// protocol, for benchmarks that should not depend on the system's
+// files. The protocols are numbered 0 to n-1 and named "proto-000",
+// "proto-001" and so on. The services of each protocol use the ports
+// 1 to n and are named "svc-001", "svc-002" and so on, so the same
+// name exists once per protocol. Numbers are not limited to
+// netdb.MaxProtocolNumber. The result is the same for every call.
+func Synthetic(n int) (protocols []*netdb.Protoent, services []*netdb.Servent) {
+	protocols = make([]*netdb.Protoent, 0, n)
+	services = make([]*netdb.Servent, 0, n*n)
+	for i := 0; i < n; i++ {
+		protoent := &netdb.Protoent{
+			Name:    fmt.Sprintf("proto-%03d", i),
+			Aliases: []string{},
+			Number:  i,
+		}
+		protocols = append(protocols, protoent)
+
+		for port := 1; port <= n; port++ {
+			services = append(services, &netdb.Servent{
+				Name:     fmt.Sprintf("svc-%03d", port),
+				Aliases:  []string{},
+				Port:     port,
+				Protocol: protoent,
+			})
+		}
+	}
+	return protocols, services
+}
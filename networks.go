@@ -0,0 +1,62 @@
+package netdb
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// A Netent is an entry of /etc/networks.
+type Netent struct {
+	Name    string
+	Aliases []string
+	Net     net.IP
+}
+
+// ParseNetworkLine parses a single line in the format of
+// /etc/networks. Network numbers with fewer than four components,
+// such as "127", are padded with zeros. If the line doesn't contain
+// an entry, for example because it is empty or only holds a comment,
+// nil is returned. Missing or invalid network numbers result in a
+// *ParseError.
+func ParseNetworkLine(line string) (*Netent, error) {
+	line = strings.TrimSpace(line)
+	split := strings.SplitN(line, "#", 2)
+	fields := strings.Fields(split[0])
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if len(fields) < 2 {
+		return nil, &ParseError{Line: line, Err: errors.New("missing network number")}
+	}
+
+	ip := parseNetworkNumber(fields[1])
+	if ip == nil {
+		return nil, &ParseError{Line: line, Err: fmt.Errorf("invalid network number %q", fields[1])}
+	}
+
+	return &Netent{
+		Name:    fields[0],
+		Aliases: fields[2:],
+		Net:     ip,
+	}, nil
+}
+
+func parseNetworkNumber(s string) net.IP {
+	parts := strings.Split(s, ".")
+	if len(parts) > 4 {
+		return nil
+	}
+
+	ip := make(net.IP, 4)
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return nil
+		}
+		ip[i] = byte(n)
+	}
+	return ip
+}
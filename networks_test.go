@@ -0,0 +1,43 @@
+package netdb_test
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"honnef.co/go/netdb"
+)
+
+func TestParseNetworkLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want *netdb.Netent
+	}{
+		{"loopback 127", &netdb.Netent{Name: "loopback", Aliases: []string{}, Net: net.IPv4(127, 0, 0, 0).To4()}},
+		{"link-local\t169.254.0.0 ll # RFC 3927", &netdb.Netent{Name: "link-local", Aliases: []string{"ll"}, Net: net.IPv4(169, 254, 0, 0).To4()}},
+		{"# comment", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := netdb.ParseNetworkLine(tt.line)
+		if err != nil {
+			t.Errorf("%q: %v", tt.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseNetworkLineErrors(t *testing.T) {
+	for _, line := range []string{"loopback", "loopback # no number", "loopback 127.0.0.0.1", "loopback 256"} {
+		_, err := netdb.ParseNetworkLine(line)
+		var parseErr *netdb.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: got error %v, want a *ParseError", line, err)
+		}
+	}
+}
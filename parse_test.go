@@ -0,0 +1,36 @@
+package netdb_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"honnef.co/go/netdb"
+	"honnef.co/go/netdb/netdbtest"
+)
+
+func TestParseErrorChain(t *testing.T) {
+	_, err := netdb.ParseProtocolsBytes([]byte("tcp 6 TCP\nudp seventeen UDP\n"))
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Fatalf("got error %v, want one wrapping *strconv.NumError", err)
+	}
+	if numErr.Num != "seventeen" {
+		t.Errorf("got NumError for %q, want %q", numErr.Num, "seventeen")
+	}
+}
+
+func TestParseServicesBytesResolvesAliases(t *testing.T) {
+	netdbtest.UseFixture(t)
+
+	services, err := netdb.ParseServicesBytes([]byte("http 80/tcp\ndomain 53/UDP\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := services[0].Protocol, netdb.GetProtoByName("tcp"); got != want {
+		t.Errorf("http: got protocol %v, want %v", got, want)
+	}
+	if got, want := services[1].Protocol, netdb.GetProtoByName("udp"); got != want {
+		t.Errorf("domain: got protocol %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package netdb
+
+// GetDefaultProtocolsPath returns the path of the system's protocols
+// database.
+func GetDefaultProtocolsPath() string {
+	return "/etc/protocols"
+}
+
+// GetDefaultServicesPath returns the path of the system's services
+// database.
+func GetDefaultServicesPath() string {
+	return "/etc/services"
+}
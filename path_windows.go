@@ -0,0 +1,28 @@
+//go:build windows
+
+package netdb
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// GetDefaultProtocolsPath returns the path of the system's protocols
+// database.
+func GetDefaultProtocolsPath() string {
+	return filepath.Join(etcDir(), "protocol")
+}
+
+// GetDefaultServicesPath returns the path of the system's services
+// database.
+func GetDefaultServicesPath() string {
+	return filepath.Join(etcDir(), "services")
+}
+
+func etcDir() string {
+	root := os.Getenv("SystemRoot")
+	if root == "" {
+		root = `C:\Windows`
+	}
+	return filepath.Join(root, "System32", "drivers", "etc")
+}
@@ -0,0 +1,119 @@
+package netdb
+
+import "strings"
+
+// A PortProtoKey identifies a service by its port and the name of its
+// protocol. Use NewPortProtoKey to construct keys that compare equal
+// to the ones used by this package.
+type PortProtoKey struct {
+	Port  int
+	Proto string
+}
+
+// NewPortProtoKey returns the key for a port and protocol name. The
+// name is converted to lower case.
+func NewPortProtoKey(port int, proto string) PortProtoKey {
+	return PortProtoKey{Port: port, Proto: strings.ToLower(proto)}
+}
+
+func (this *Servent) key() PortProtoKey {
+	var proto string
+	if this.Protocol != nil {
+		proto = this.Protocol.Name
+	}
+	return NewPortProtoKey(this.Port, proto)
+}
+
+// PruneProtocols removes all protocols whose number is not in allowed
+// from Protocols and returns the number of removed entries.
+func PruneProtocols(allowed []int) int {
+	numbers := make(map[int]struct{}, len(allowed))
+	for _, num := range allowed {
+		numbers[num] = struct{}{}
+	}
+
+	return filterProtocols(func(protoent *Protoent) bool {
+		_, ok := numbers[protoent.Number]
+		return ok
+	})
+}
+
+// PruneServices removes all services whose port and protocol are not
+// in allowed from Services and returns the number of removed entries.
+func PruneServices(allowed map[PortProtoKey]struct{}) int {
+	return filterServices(func(servent *Servent) bool {
+		_, ok := allowed[servent.key()]
+		return ok
+	})
+}
+
+// PruneByPortRange removes all services of a protocol whose port is
+// outside [min, max] from Services and returns the number of removed
+// entries. If the protocol is nil, services of all protocols are
+// considered.
+func PruneByPortRange(min, max int, protocol *Protoent) int {
+	return filterServices(func(servent *Servent) bool {
+		return !servent.hasProtocol(protocol) || (servent.Port >= min && servent.Port <= max)
+	})
+}
+
+// PruneByProtocolNumbers removes all protocols whose number is
+// outside [min, max] from Protocols and returns the number of removed
+// entries.
+func PruneByProtocolNumbers(min, max int) int {
+	return filterProtocols(func(protoent *Protoent) bool {
+		return protoent.Number >= min && protoent.Number <= max
+	})
+}
+
+// Shrink reallocates Protocols and Services to release unused
+// capacity, for example after pruning many entries. It does nothing
+// if the slices have no excess capacity.
+func Shrink() {
+	if cap(Protocols) > len(Protocols) {
+		protocols := make([]*Protoent, len(Protocols))
+		copy(protocols, Protocols)
+		Protocols = protocols
+	}
+	if cap(Services) > len(Services) {
+		services := make([]*Servent, len(Services))
+		copy(services, Services)
+		Services = services
+	}
+}
+
+// filterProtocols removes all protocols for which keep returns false
+// and returns the number of removed entries.
+func filterProtocols(keep func(*Protoent) bool) int {
+	kept := Protocols[:0]
+	for _, protoent := range Protocols {
+		if keep(protoent) {
+			kept = append(kept, protoent)
+		}
+	}
+
+	removed := len(Protocols) - len(kept)
+	for i := len(kept); i < len(Protocols); i++ {
+		Protocols[i] = nil
+	}
+	Protocols = kept
+	return removed
+}
+
+// filterServices removes all services for which keep returns false
+// and returns the number of removed entries.
+func filterServices(keep func(*Servent) bool) int {
+	kept := Services[:0]
+	for _, servent := range Services {
+		if keep(servent) {
+			kept = append(kept, servent)
+		}
+	}
+
+	removed := len(Services) - len(kept)
+	for i := len(kept); i < len(Services); i++ {
+		Services[i] = nil
+	}
+	Services = kept
+	return removed
+}
@@ -0,0 +1,105 @@
+package netdb
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// schemes maps URL schemes to the port and protocol of their default
+// service. Many scheme names differ from the service names used in
+// /etc/services, such as "ws" or "pop3s".
+var schemes = map[string]struct {
+	port  int
+	proto string
+}{
+	"http":   {80, "tcp"},
+	"https":  {443, "tcp"},
+	"ws":     {80, "tcp"},
+	"wss":    {443, "tcp"},
+	"ftp":    {21, "tcp"},
+	"ftps":   {990, "tcp"},
+	"ssh":    {22, "tcp"},
+	"sftp":   {22, "tcp"},
+	"telnet": {23, "tcp"},
+	"smtp":   {25, "tcp"},
+	"dns":    {53, "udp"},
+	"gopher": {70, "tcp"},
+	"pop3":   {110, "tcp"},
+	"pop3s":  {995, "tcp"},
+	"nntp":   {119, "tcp"},
+	"news":   {119, "tcp"},
+	"imap":   {143, "tcp"},
+	"imaps":  {993, "tcp"},
+	"snmp":   {161, "udp"},
+	"ldap":   {389, "tcp"},
+	"ldaps":  {636, "tcp"},
+	"rtsp":   {554, "tcp"},
+	"ipp":    {631, "tcp"},
+	"rsync":  {873, "tcp"},
+	"git":    {9418, "tcp"},
+}
+
+var (
+	registeredSchemesMu sync.RWMutex
+	registeredSchemes   = make(map[string]*Servent)
+)
+
+// RegisterScheme registers the port and protocol of a custom URL
+// scheme for LookupByScheme, taking precedence over the built-in
+// table. Registering a scheme again replaces the previous entry. It
+// is an error for the scheme to be empty, for the port to be outside
+// [MinServicePort, MaxServicePort] or for the protocol to be nil.
+// RegisterScheme is safe for concurrent use with LookupByScheme.
+func RegisterScheme(scheme string, port int, protocol *Protoent) error {
+	if scheme == "" {
+		return errors.New("netdb: empty scheme")
+	}
+	if _, err := NewServicePort(port); err != nil {
+		return err
+	}
+	if protocol == nil {
+		return errors.New("netdb: scheme " + scheme + " has no protocol")
+	}
+
+	registeredSchemesMu.Lock()
+	defer registeredSchemesMu.Unlock()
+	registeredSchemes[strings.ToLower(scheme)] = &Servent{
+		Name:     scheme,
+		Port:     port,
+		Protocol: protocol,
+	}
+	return nil
+}
+
+// LookupByScheme returns the service for the default port of a URL
+// scheme, such as 443/tcp for "https". Schemes are case-insensitive.
+// Schemes registered with RegisterScheme are checked first; if
+// Services has no service on the registered port and protocol, a
+// Servent named after the scheme is returned. Schemes that are not
+// in the built-in table are looked up as service names of any
+// protocol. It returns nil if no service matches.
+func LookupByScheme(scheme string) *Servent {
+	scheme = strings.ToLower(scheme)
+
+	registeredSchemesMu.RLock()
+	registered := registeredSchemes[scheme]
+	registeredSchemesMu.RUnlock()
+	if registered != nil {
+		if servent := GetServByPort(registered.Port, registered.Protocol); servent != nil {
+			return servent
+		}
+		return registered
+	}
+
+	if s, ok := schemes[scheme]; ok {
+		if protocol := GetProtoByName(s.proto); protocol != nil {
+			if servent := GetServByPort(s.port, protocol); servent != nil {
+				return servent
+			}
+		}
+	}
+	return findService(func(servent *Servent) bool {
+		return servent.hasName(scheme)
+	})
+}
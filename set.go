@@ -0,0 +1,152 @@
+package netdb
+
+// A ProtocolSet is an immutable snapshot of Protocols. It is safe for
+// concurrent use and is not affected by later changes to Protocols.
+type ProtocolSet struct {
+	list     []*Protoent
+	byName   map[string]*Protoent
+	byNumber map[int]*Protoent
+}
+
+// A ServiceSet is an immutable snapshot of Services. It is safe for
+// concurrent use and is not affected by later changes to Services.
+type ServiceSet struct {
+	list   []*Servent
+	byName map[string]*Servent
+	byPort map[int][]*Servent
+}
+
+// FreezeProtocols returns a snapshot of Protocols.
+func FreezeProtocols() ProtocolSet {
+	set := ProtocolSet{
+		list:     make([]*Protoent, 0, len(Protocols)),
+		byName:   make(map[string]*Protoent),
+		byNumber: make(map[int]*Protoent, len(Protocols)),
+	}
+
+	for _, protoent := range Protocols {
+		protoent = protoent.clone()
+		set.list = append(set.list, protoent)
+
+		if _, ok := set.byNumber[protoent.Number]; !ok {
+			set.byNumber[protoent.Number] = protoent
+		}
+		for _, name := range append([]string{protoent.Name}, protoent.Aliases...) {
+			if _, ok := set.byName[name]; !ok {
+				set.byName[name] = protoent
+			}
+		}
+	}
+
+	return set
+}
+
+// FreezeServices returns a snapshot of Services.
+func FreezeServices() ServiceSet {
+	set := ServiceSet{
+		list:   make([]*Servent, 0, len(Services)),
+		byName: make(map[string]*Servent),
+		byPort: make(map[int][]*Servent),
+	}
+
+	for _, servent := range Services {
+		servent = servent.clone()
+		set.list = append(set.list, servent)
+
+		set.byPort[servent.Port] = append(set.byPort[servent.Port], servent)
+		for _, name := range append([]string{servent.Name}, servent.Aliases...) {
+			if _, ok := set.byName[name]; !ok {
+				set.byName[name] = servent
+			}
+		}
+	}
+
+	return set
+}
+
+// Len returns the number of protocols in the set.
+func (this ProtocolSet) Len() int {
+	return len(this.list)
+}
+
+// Contains reports whether the set has a protocol whose name or any
+// of its aliases matches the argument.
+func (this ProtocolSet) Contains(name string) bool {
+	_, ok := this.byName[name]
+	return ok
+}
+
+// ByName returns a copy of the protocol whose name or any of its
+// aliases matches the argument.
+func (this ProtocolSet) ByName(name string) (Protoent, bool) {
+	protoent, ok := this.byName[name]
+	if !ok {
+		return Protoent{}, false
+	}
+	return *protoent.clone(), true
+}
+
+// ByNumber returns a copy of the protocol with the given number.
+func (this ProtocolSet) ByNumber(num int) (Protoent, bool) {
+	protoent, ok := this.byNumber[num]
+	if !ok {
+		return Protoent{}, false
+	}
+	return *protoent.clone(), true
+}
+
+// Iterate calls fn with a copy of every protocol in the set, in the
+// order of Protocols at the time of the snapshot, until fn returns
+// false.
+func (this ProtocolSet) Iterate(fn func(Protoent) bool) {
+	for _, protoent := range this.list {
+		if !fn(*protoent.clone()) {
+			return
+		}
+	}
+}
+
+// Len returns the number of services in the set.
+func (this ServiceSet) Len() int {
+	return len(this.list)
+}
+
+// Contains reports whether the set has a service whose name or any
+// of its aliases matches the argument.
+func (this ServiceSet) Contains(name string) bool {
+	_, ok := this.byName[name]
+	return ok
+}
+
+// ByName returns a copy of the first service whose name or any of
+// its aliases matches the argument.
+func (this ServiceSet) ByName(name string) (Servent, bool) {
+	servent, ok := this.byName[name]
+	if !ok {
+		return Servent{}, false
+	}
+	return *servent.clone(), true
+}
+
+// ByPort returns a copy of the service for a given port number and
+// protocol. If the protocol is nil, the first service matching the
+// port number is returned.
+func (this ServiceSet) ByPort(port int, protocol *Protoent) (Servent, bool) {
+	for _, servent := range this.byPort[port] {
+		if servent.hasProtocol(protocol) {
+			return *servent.clone(), true
+		}
+	}
+	return Servent{}, false
+}
+
+// Iterate calls fn with a copy of every service in the set, in the
+// order of Services at the time of the snapshot, until fn returns
+// false.
+func (this ServiceSet) Iterate(fn func(Servent) bool) {
+	for _, servent := range this.list {
+		if !fn(*servent.clone()) {
+			return
+		}
+	}
+}
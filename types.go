@@ -0,0 +1,63 @@
+package netdb
+
+import (
+	"errors"
+	"strconv"
+)
+
+// A ProtocolNumber is an IP protocol number.
+type ProtocolNumber uint8
+
+// String returns the name of the protocol, or the number in decimal if
+// it is not in Protocols.
+func (this ProtocolNumber) String() string {
+	if protoent := GetProtoByNumber(int(this)); protoent != nil {
+		return protoent.Name
+	}
+	return strconv.Itoa(int(this))
+}
+
+// IsAssigned reports whether Protocols has an entry for the number.
+func (this ProtocolNumber) IsAssigned() bool {
+	return GetProtoByNumber(int(this)) != nil
+}
+
+// A ServicePort is a TCP, UDP, SCTP or DCCP port number.
+type ServicePort uint16
+
+// NewServicePort returns n as a ServicePort. It is an error for n to
+// be outside [MinServicePort, MaxServicePort].
+func NewServicePort(n int) (ServicePort, error) {
+	if n < MinServicePort || n > MaxServicePort {
+		return 0, errors.New("netdb: port " + strconv.Itoa(n) + " out of range")
+	}
+	return ServicePort(n), nil
+}
+
+// String returns the name of the first service on the port, or the
+// port in decimal if there is no such service.
+func (this ServicePort) String() string {
+	port := int(this)
+	if servent := findService(func(servent *Servent) bool { return servent.Port == port }); servent != nil {
+		return servent.Name
+	}
+	return strconv.Itoa(port)
+}
+
+// IsWellKnown reports whether the port is in the well-known range
+// [0, 1023].
+func (this ServicePort) IsWellKnown() bool {
+	return this <= 1023
+}
+
+// IsRegistered reports whether the port is in the registered range
+// [1024, 49151].
+func (this ServicePort) IsRegistered() bool {
+	return this >= 1024 && this <= 49151
+}
+
+// IsDynamic reports whether the port is in the dynamic range
+// [49152, 65535].
+func (this ServicePort) IsDynamic() bool {
+	return this >= 49152
+}
@@ -0,0 +1,38 @@
+package netdb
+
+// The functions in this file return freshly allocated entries for
+// well-known services, as assigned by IANA. They don't consult
+// Protocols or Services.
+
+func newTCP() *Protoent {
+	return &Protoent{Name: "tcp", Aliases: []string{"TCP"}, Number: 6}
+}
+
+func newUDP() *Protoent {
+	return &Protoent{Name: "udp", Aliases: []string{"UDP"}, Number: 17}
+}
+
+// NewHTTPServent returns the entry for http, 80/tcp.
+func NewHTTPServent() *Servent {
+	return &Servent{Name: "http", Aliases: []string{"www"}, Port: 80, Protocol: newTCP()}
+}
+
+// NewHTTPSServent returns the entry for https, 443/tcp.
+func NewHTTPSServent() *Servent {
+	return &Servent{Name: "https", Aliases: []string{}, Port: 443, Protocol: newTCP()}
+}
+
+// NewSSHServent returns the entry for ssh, 22/tcp.
+func NewSSHServent() *Servent {
+	return &Servent{Name: "ssh", Aliases: []string{}, Port: 22, Protocol: newTCP()}
+}
+
+// NewDNSTCPServent returns the entry for domain, 53/tcp.
+func NewDNSTCPServent() *Servent {
+	return &Servent{Name: "domain", Aliases: []string{}, Port: 53, Protocol: newTCP()}
+}
+
+// NewDNSUDPServent returns the entry for domain, 53/udp.
+func NewDNSUDPServent() *Servent {
+	return &Servent{Name: "domain", Aliases: []string{}, Port: 53, Protocol: newUDP()}
+}